@@ -1,26 +1,42 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
 	"sync"
-	"syscall"
+	"sync/atomic"
 
 	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
 	"github.com/bmc-toolbox/bmcbutler/pkg/butler"
+	"github.com/bmc-toolbox/bmcbutler/pkg/deadletter"
 	"github.com/bmc-toolbox/bmcbutler/pkg/inventory"
+	promMetrics "github.com/bmc-toolbox/bmcbutler/pkg/metrics"
+	"github.com/bmc-toolbox/bmcbutler/pkg/publisher"
+	"github.com/bmc-toolbox/bmcbutler/pkg/report"
 	"github.com/bmc-toolbox/bmcbutler/pkg/secrets"
+	"github.com/bmc-toolbox/bmcbutler/pkg/tracing"
 	metrics "github.com/bmc-toolbox/gin-go-metrics"
 )
 
 var (
-	butlers   *butler.Butler
-	commandWG sync.WaitGroup
-	interrupt bool
+	butlers         *butler.Butler
+	commandWG       sync.WaitGroup
+	interrupt       bool
+	tracingShutdown func(context.Context) error
 )
 
+// runSummary is emitted as a single JSON line once all butlers have
+// finished or been interrupted, so operators can tell at a glance how a
+// run landed without grepping through logrus output.
+type runSummary struct {
+	Completed   int32 `json:"completed"`
+	Interrupted int32 `json:"interrupted"`
+	Failed      int32 `json:"failed"`
+}
+
 // post handles clean up actions
 // - closes the butler channel
 // - Waits for all go routines in commandWG to finish.
@@ -28,6 +44,36 @@ func post(butlerChan chan butler.Msg) {
 	close(butlerChan)
 	commandWG.Wait()
 	metrics.Close(true)
+
+	if butlers != nil && butlers.Publishers != nil {
+		if err := butlers.Publishers.Close(); err != nil {
+			log.WithError(err).Warn("Error closing publishers.")
+		}
+	}
+
+	if butlers != nil && butlers.DeadLetter != nil {
+		if err := butlers.DeadLetter.Close(); err != nil {
+			log.WithError(err).Warn("Error closing dead-letter sink.")
+		}
+	}
+
+	if tracingShutdown != nil {
+		if err := tracingShutdown(context.Background()); err != nil {
+			log.WithError(err).Warn("Error shutting down tracing.")
+		}
+	}
+
+	if butlers != nil {
+		summary := runSummary{
+			Completed:   atomic.LoadInt32(&butlers.Completed),
+			Interrupted: atomic.LoadInt32(&butlers.Interrupted),
+			Failed:      atomic.LoadInt32(&butlers.Failed),
+		}
+
+		if out, err := json.Marshal(summary); err == nil {
+			fmt.Println(string(out))
+		}
+	}
 }
 
 // Any flags to override configuration goes here.
@@ -63,8 +109,9 @@ func prepareChannels() (inventoryChan chan []asset.Asset, butlerChan chan butler
 	overrideConfigFromFlags()
 	runConfig.Load(runConfig.CfgFile)
 
-	// Used to indicate Go routines to exit.
-	stopChan = make(chan struct{})
+	// shutdownChan is set up by setupSignalHandling() in rootCmd.PersistentPreRun,
+	// ahead of any butler being spawned.
+	stopChan = shutdownChan
 
 	err := metrics.Setup(
 		runConfig.Metrics.Client,
@@ -78,55 +125,50 @@ func prepareChannels() (inventoryChan chan []asset.Asset, butlerChan chan butler
 		os.Exit(1)
 	}
 
+	if runConfig.MetricsListen != "" {
+		go promMetrics.Serve(runConfig.MetricsListen, log)
+	}
+
+	tracingShutdown, err = tracing.Init(runConfig.OtlpEndpoint)
+	if err != nil {
+		log.WithError(err).Warn("Failed to set up tracing, continuing without it.")
+	}
+
 	// A channel to receive inventory assets.
 	inventoryChan = make(chan []asset.Asset, 5)
 
-	// Determine inventory to fetch asset data.
+	// inventoryCtx is cancelled as soon as stopChan closes, so a long-running
+	// fetch (a Dora/Redfish HTTP call, an enc exec) is abandoned promptly on
+	// SIGINT instead of being left to run to completion.
+	inventoryCtx, cancelInventory := context.WithCancel(context.Background())
+	go func() { <-stopChan; cancelInventory() }()
+
+	// Determine inventory source(s) to fetch asset data from. A comma
+	// separated list fans multiple providers into the same inventory
+	// channel via inventory.MultiSource, e.g. "redfish,csv".
 	inventorySource := runConfig.Inventory.Source
+	sourceNames := strings.Split(inventorySource, ",")
 
 	// Based on inventory source, invoke assetRetriever:
 	var assetRetriever func()
 
-	switch inventorySource {
-	case "enc":
-		inventoryInstance := inventory.Enc{
-			Config:     runConfig,
-			Log:        log,
-			BatchSize:  10,
-			AssetsChan: inventoryChan,
-			StopChan:   stopChan,
-		}
-
-		assetRetriever = inventoryInstance.AssetRetrieve()
-	case "csv":
-		inventoryInstance := inventory.Csv{
-			Config:     runConfig,
-			Log:        log,
-			AssetsChan: inventoryChan,
-		}
-
-		assetRetriever = inventoryInstance.AssetRetrieve()
-	case "dora":
-		inventoryInstance := inventory.Dora{
-			Config:     runConfig,
-			Log:        log,
-			BatchSize:  10,
-			AssetsChan: inventoryChan,
+	switch {
+	case len(sourceNames) > 1:
+		multiSource, err := inventory.NewMultiSource(inventoryCtx, sourceNames, runConfig, log, inventoryChan, stopChan)
+		if err != nil {
+			fmt.Println("Unable to set up inventory sources: ", err)
+			os.Exit(1)
 		}
 
-		assetRetriever = inventoryInstance.AssetRetrieve()
-	case "iplist":
-		inventoryInstance := inventory.IPList{
-			Channel:   inventoryChan,
-			Config:    runConfig,
-			BatchSize: 1,
-			Log:       log,
+		assetRetriever = multiSource.AssetRetrieve()
+	default:
+		source, err := inventory.New(inventoryCtx, inventorySource, runConfig, log, inventoryChan, stopChan)
+		if err != nil {
+			fmt.Println("Unable to set up inventory source: ", err)
+			os.Exit(1)
 		}
 
-		assetRetriever = inventoryInstance.AssetRetrieve()
-	default:
-		fmt.Println("Unknown/no inventory source declared in cfg: ", inventorySource)
-		os.Exit(1)
+		assetRetriever = source.AssetRetrieve()
 	}
 
 	// This routine returns assets over the inventoryChan.
@@ -135,28 +177,41 @@ func prepareChannels() (inventoryChan chan []asset.Asset, butlerChan chan butler
 	// Spawn butlers to work
 	butlerChan = make(chan butler.Msg, 2)
 
+	publishers, err := publisher.New(runConfig)
+	if err != nil {
+		log.WithError(err).Fatal("Unable to set up publishers.")
+	}
+
+	deadLetterSink, err := deadletter.NewSink(runConfig.DeadLetter)
+	if err != nil {
+		log.WithError(err).Fatal("Unable to set up dead-letter sink.")
+	}
+
 	butlers = &butler.Butler{
 		ButlerChan: butlerChan,
+		RetryChan:  butlerChan,
 		StopChan:   stopChan,
 		Config:     runConfig,
 		Log:        log,
 		SyncWG:     &commandWG,
+		ReportSink: report.NewSink(runConfig.Report),
+		Publishers: publisher.NewFanout(log, publishers),
+		DeadLetter: deadLetterSink,
 	}
 
-	if runConfig.SecretsFromVault {
-		store, err := secrets.Load(*runConfig.Vault)
-		if err != nil {
-			log.Fatalf("[Error] loading secrets from vault: %s", err.Error())
-		}
+	if runConfig.SecretsFromVault || secrets.HasReferences(runConfig) {
+		store := secrets.Load(runConfig)
 
-		runConfig.Credentials, err = store.SetCredentials(runConfig.Credentials)
+		runConfig.Credentials, err = secrets.ResolveCredentials(store, runConfig.Credentials)
 		if err != nil {
-			log.Fatalf("[Error] loading secrets from vault: %s", err.Error())
+			log.Fatalf("[Error] resolving credential secrets: %s", err.Error())
 		}
 
-		runConfig.CertSigner.LemurSigner.Key, err = store.GetSignerToken(runConfig.CertSigner.LemurSigner.Key)
-		if err != nil {
-			log.Fatalf("[Error] loading secrets from vault: %s", err.Error())
+		if runConfig.CertSigner.LemurSigner.Key != "" {
+			runConfig.CertSigner.LemurSigner.Key, err = store.GetToken(runConfig.CertSigner.LemurSigner.Key)
+			if err != nil {
+				log.Fatalf("[Error] resolving cert signer token: %s", err.Error())
+			}
 		}
 
 		butlers.Secrets = store
@@ -165,18 +220,5 @@ func prepareChannels() (inventoryChan chan []asset.Asset, butlerChan chan butler
 	go butlers.Runner()
 	commandWG.Add(1)
 
-	signalsChan := make(chan os.Signal, 1)
-	signal.Notify(signalsChan, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		select {
-		case <-signalsChan:
-			interrupt = true
-			log.Warn("Interrupt SIGINT/SIGTERM received.")
-			close(stopChan)
-		case <-stopChan:
-			return
-		}
-	}()
-
 	return inventoryChan, butlerChan, stopChan
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"log/syslog"
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	logrusSyslog "github.com/sirupsen/logrus/hooks/syslog"
@@ -45,6 +46,7 @@ var rootCmd = &cobra.Command{
 		// Setup the logger before we run our code.
 		// This happens after init() which evaluates the CLI flags (needed to setup the logging).
 		setupLogger()
+		setupSignalHandling()
 	},
 }
 
@@ -59,25 +61,59 @@ func Execute() {
 
 func setupLogger() {
 	log = logrus.New()
-	log.Out = os.Stdout
+	log.Out = setupLogOutput(runConfig.LogOutput)
+
+	if !runConfig.NoSyslog {
+		hook, err := logrusSyslog.NewSyslogHook("", "", syslog.LOG_INFO, "BMCbutler")
+		if err != nil {
+			log.Error("Unable to connect to local syslog daemon.")
+		} else {
+			log.AddHook(hook)
+		}
+	}
 
-	hook, err := logrusSyslog.NewSyslogHook("", "", syslog.LOG_INFO, "BMCbutler")
-	if err != nil {
-		log.Error("Unable to connect to local syslog daemon.")
+	if runConfig.LogLevel != "" {
+		level, err := logrus.ParseLevel(runConfig.LogLevel)
+		if err != nil {
+			log.Errorf("Invalid --log-level %q, defaulting to info.", runConfig.LogLevel)
+			level = logrus.InfoLevel
+		}
+		log.SetLevel(level)
 	} else {
-		log.AddHook(hook)
+		switch {
+		case runConfig.Debug:
+			log.SetLevel(logrus.DebugLevel)
+		case runConfig.Trace:
+			log.SetLevel(logrus.TraceLevel)
+		default:
+			log.SetLevel(logrus.InfoLevel)
+		}
 	}
 
-	switch {
-	case runConfig.Debug:
-		log.SetLevel(logrus.DebugLevel)
-	case runConfig.Trace:
-		log.SetLevel(logrus.TraceLevel)
-	default:
-		log.SetLevel(logrus.InfoLevel)
+	if runConfig.LogFormat == "text" {
+		log.SetFormatter(&logrus.TextFormatter{})
+	} else {
+		log.SetFormatter(&logrus.JSONFormatter{})
 	}
+}
 
-	log.SetFormatter(&logrus.JSONFormatter{})
+// setupLogOutput resolves the --log-output value to a writer.
+// "stdout"/"" and "stderr" map to the respective standard streams,
+// any other value is treated as a file path to append to.
+func setupLogOutput(logOutput string) *os.File {
+	switch logOutput {
+	case "", "stdout":
+		return os.Stdout
+	case "stderr":
+		return os.Stderr
+	default:
+		f, err := os.OpenFile(logOutput, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			fmt.Printf("Unable to open --log-output %q, falling back to stdout: %s\n", logOutput, err)
+			return os.Stdout
+		}
+		return f
+	}
 }
 
 func init() {
@@ -96,6 +132,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&runConfig.FilterParams.Chassis, "chassis", "", false, "Action just Chassis assets.")
 	rootCmd.PersistentFlags().BoolVarP(&runConfig.FilterParams.Servers, "servers", "", false, "Action just Server assets.")
 	rootCmd.PersistentFlags().BoolVarP(&runConfig.DryRun, "dryrun", "", false, "Only log assets that will be actioned.")
+	rootCmd.PersistentFlags().StringVarP(&runConfig.DryRunFormat, "dryrun-format", "", "text", "Dry run diff format: text (unified diff) or json (field-level patch).")
 	rootCmd.PersistentFlags().StringVarP(&runConfig.FilterParams.Serials, "serials", "", "", "Serial(s) of the asset to setup config (separated by commas - no spaces).")
 	rootCmd.PersistentFlags().StringVarP(&runConfig.FilterParams.Ips, "ips", "", "", "IP Address(s) of the asset to setup config (separated by commas - no spaces).")
 
@@ -107,4 +144,19 @@ func init() {
 
 	// TODO: Only for execute calls, perhaps move to a more specific place?
 	rootCmd.PersistentFlags().StringVarP(&execCommand, "command", "", "", "Command to execute on BMCs.")
+
+	// Logging params.
+	rootCmd.PersistentFlags().StringVarP(&runConfig.LogFormat, "log-format", "", "json", "Log format to use (json, text).")
+	rootCmd.PersistentFlags().StringVarP(&runConfig.LogOutput, "log-output", "", "stdout", "Log output sink (stdout, stderr, or a file path).")
+	rootCmd.PersistentFlags().StringVarP(&runConfig.LogLevel, "log-level", "", "", "Log level, overrides --debug/--trace (panic, fatal, error, warn, info, debug, trace).")
+	rootCmd.PersistentFlags().BoolVarP(&runConfig.NoSyslog, "no-syslog", "", false, "Disable the local syslog hook.")
+
+	// Metrics params.
+	rootCmd.PersistentFlags().StringVarP(&runConfig.MetricsListen, "metrics-listen", "", "", "Listen address for the Prometheus /metrics and /healthz endpoints (e.g. :9090). Disabled if unset.")
+
+	// Tracing params.
+	rootCmd.PersistentFlags().StringVarP(&runConfig.OtlpEndpoint, "otlp-endpoint", "", "", "OTLP/gRPC collector address to export traces to (e.g. localhost:4317). Disabled if unset.")
+
+	// Shutdown params.
+	rootCmd.PersistentFlags().DurationVarP(&runConfig.ShutdownGrace, "shutdown-grace", "", 30*time.Second, "Grace period for in-flight butlers to wind down on SIGINT/SIGTERM before being hard-killed.")
 }
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// shutdownChan is the shared stop channel fanned out to every spawned
+// butler. It's a package-level var (rather than local to prepareChannels)
+// so PersistentPreRun can wire signals into it before any butler exists.
+var shutdownChan chan struct{}
+
+// setupSignalHandling wires OS signals into shutdownChan.
+//   - SIGINT/SIGTERM: trigger a graceful stop (close shutdownChan). A second
+//     SIGINT/SIGTERM forces an immediate exit.
+//   - SIGHUP: re-read cfgFile without restarting.
+func setupSignalHandling() {
+	shutdownChan = make(chan struct{})
+
+	signalsChan := make(chan os.Signal, 1)
+	signal.Notify(signalsChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	go func() {
+		stopRequested := false
+
+		for sig := range signalsChan {
+			switch sig {
+			case syscall.SIGHUP:
+				log.Info("SIGHUP received, reloading configuration.")
+				runConfig.Load(runConfig.CfgFile)
+				continue
+			}
+
+			if stopRequested {
+				log.Warn("Second interrupt received, forcing exit.")
+				os.Exit(1)
+			}
+
+			stopRequested = true
+			interrupt = true
+			log.Warn("Interrupt SIGINT/SIGTERM received, stopping gracefully.")
+			close(shutdownChan)
+
+			if runConfig.ShutdownGrace > 0 {
+				go func() {
+					time.Sleep(runConfig.ShutdownGrace)
+					log.WithFields(logrus.Fields{
+						"grace": runConfig.ShutdownGrace,
+					}).Warn("Shutdown grace period elapsed, forcing exit.")
+					os.Exit(1)
+				}()
+			}
+		}
+	}()
+}
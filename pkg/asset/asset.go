@@ -25,6 +25,7 @@ type Asset struct {
 	IPAddress    string
 	Serial       string
 	Vendor       string
+	Model        string
 	HardwareType string
 	Type         string // "server" or "chassis"
 	Location     string
@@ -32,4 +33,11 @@ type Asset struct {
 	Configure    bool              // If set, butlers will configure the asset.
 	Execute      bool              // If set, butlers will execute given command(s) on the asset.
 	Extra        map[string]string // Any extra params needed to be set in a asset.
+
+	// Ack and Nack, when set, are invoked once a butler finishes acting on
+	// this asset, so a message-driven inventory source (e.g. Kafka) can
+	// commit or skip the offset of the message that produced it. Left nil
+	// for polling sources that have nothing to acknowledge.
+	Ack  func() `json:"-"`
+	Nack func() `json:"-"`
 }
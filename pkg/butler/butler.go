@@ -15,7 +15,9 @@
 package butler
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gammazero/workerpool"
@@ -23,8 +25,17 @@ import (
 
 	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
 	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+	"github.com/bmc-toolbox/bmcbutler/pkg/deadletter"
+	"github.com/bmc-toolbox/bmcbutler/pkg/metrics"
+	"github.com/bmc-toolbox/bmcbutler/pkg/publisher"
+	"github.com/bmc-toolbox/bmcbutler/pkg/report"
+	"github.com/bmc-toolbox/bmcbutler/pkg/secrets"
 )
 
+// defaultPerAssetTimeout bounds how long a single asset's Configure/Execute
+// is given to finish when Config.PerAssetTimeout is unset.
+const defaultPerAssetTimeout = 10 * time.Minute
+
 // Msg (butler messages) are passed over the butlerChan
 // they declare assets for butlers to carry actions on.
 type Msg struct {
@@ -32,6 +43,30 @@ type Msg struct {
 	AssetConfig  []byte      //The BMC configuration read in from configuration.yml
 	AssetSetup   []byte      //The One time setup configuration read from setup.yml
 	AssetExecute string      //Commands to be executed on the BMC
+
+	// Attempt counts how many times this Msg has been handed to msgHandler,
+	// starting at 0. Incremented by retryOrDeadLetter each time a transient
+	// failure sends it back onto RetryChan, until it reaches Config.Retry's
+	// MaxAttempts and is dead-lettered instead.
+	Attempt int
+}
+
+// Ack tells the asset's inventory source (if message-driven, e.g. Kafka)
+// that msgHandler finished this Msg successfully and its offset can be
+// committed. A no-op if the asset's source doesn't use acknowledgement.
+func (m Msg) Ack() {
+	if m.Asset.Ack != nil {
+		m.Asset.Ack()
+	}
+}
+
+// Nack tells the asset's inventory source (if message-driven, e.g. Kafka)
+// that msgHandler failed this Msg, so its offset should not be committed
+// and the message can be redelivered.
+func (m Msg) Nack() {
+	if m.Asset.Nack != nil {
+		m.Asset.Nack()
+	}
 }
 
 // Butler struct holds attributes required to spawn butlers.
@@ -42,7 +77,58 @@ type Butler struct {
 	StopChan   <-chan struct{}
 	SyncWG     *sync.WaitGroup
 	WorkerPool *workerpool.WorkerPool
-	interrupt  bool
+	active     int32
+
+	// RetryChan, when set, is where a Msg that failed with a transient error
+	// is re-enqueued after its backoff delay. Normally the same channel as
+	// ButlerChan, given a writable handle. Left nil, transient failures are
+	// dead-lettered immediately like permanent ones.
+	RetryChan chan<- Msg
+
+	// DeadLetter, when set, receives every asset action that exhausted its
+	// retry budget or failed with a permanent error.
+	DeadLetter deadletter.Sink
+
+	// Secrets, when set, resolves credential/token references for backends
+	// beyond what's already been resolved into Config by prepareChannels.
+	Secrets secrets.Store
+
+	// ctx is the root context for this Butler's lifetime, derived from
+	// StopChan by Runner - cancelled as soon as StopChan closes.
+	ctx context.Context
+
+	// ReportSink, when set, receives a structured RunReport for every asset
+	// this Butler configures, for post-run auditing.
+	ReportSink report.ReportSink
+
+	// Publishers, when set, receives the outcome of every Configure/Execute
+	// action, fanned out to the configured external publishers.
+	Publishers *publisher.Fanout
+
+	// Completed, Interrupted and Failed tally asset outcomes across this
+	// Butler's lifetime, for the run summary emitted by cmd's post().
+	Completed   int32
+	Interrupted int32
+	Failed      int32
+}
+
+// publish fans result out to b.Publishers, if configured.
+func (b *Butler) publish(result publisher.AssetResult) {
+	if b.Publishers == nil {
+		return
+	}
+
+	b.Publishers.Publish(result)
+}
+
+// perAssetTimeout returns Config.PerAssetTimeout, defaulting to
+// defaultPerAssetTimeout when unset.
+func (b *Butler) perAssetTimeout() time.Duration {
+	if b.Config.PerAssetTimeout > 0 {
+		return b.Config.PerAssetTimeout
+	}
+
+	return defaultPerAssetTimeout
 }
 
 // Runner spawns a pool of butlers, waits until they are done.
@@ -53,6 +139,12 @@ func (b *Butler) Runner() {
 
 	defer b.SyncWG.Done()
 
+	// root is cancelled as soon as StopChan closes, so every in-flight
+	// msgHandler's per-message context is cancelled along with it.
+	root, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b.ctx = root
+
 	b.WorkerPool = workerpool.New(b.Config.ButlersToSpawn)
 loop:
 	for {
@@ -74,9 +166,22 @@ loop:
 				time.Sleep(10 * time.Second)
 			}
 
-			b.WorkerPool.Submit(func() { b.msgHandler(msg) })
+			metrics.SetQueuedAssets(b.WorkerPool.WaitingQueueSize())
+			b.WorkerPool.Submit(func() {
+				atomic.AddInt32(&b.active, 1)
+				metrics.SetActiveButlers(int(atomic.LoadInt32(&b.active)))
+				defer func() {
+					atomic.AddInt32(&b.active, -1)
+					metrics.SetActiveButlers(int(atomic.LoadInt32(&b.active)))
+				}()
+
+				msgCtx, msgCancel := context.WithTimeout(b.ctx, b.perAssetTimeout())
+				defer msgCancel()
+
+				b.msgHandler(msgCtx, msg)
+			})
 		case <-b.StopChan:
-			b.interrupt = true
+			cancel()
 			log.WithFields(logrus.Fields{
 				"component":          component,
 				"Waiting queue size": b.WorkerPool.WaitingQueueSize(),
@@ -1,38 +1,77 @@
 package butler
 
 import (
+	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/bmc-toolbox/bmclogin"
 
 	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
 	"github.com/bmc-toolbox/bmcbutler/pkg/butler/configure"
+	"github.com/bmc-toolbox/bmcbutler/pkg/metrics"
+	"github.com/bmc-toolbox/bmcbutler/pkg/report"
 	"github.com/bmc-toolbox/bmcbutler/pkg/resource"
+	"github.com/bmc-toolbox/bmcbutler/pkg/tracing"
 	"github.com/bmc-toolbox/bmclib/devices"
 )
 
 // applyConfig setups up the bmc connection
 // gets any Asset config templated data rendered
 // applies the asset configuration using bmclib
-func (b *Butler) configureAsset(config []byte, asset *asset.Asset) (err error) {
+func (b *Butler) configureAsset(ctx context.Context, config []byte, asset *asset.Asset) (err error) {
 
 	log := b.Log
 	component := "configureAsset"
 	metric := b.MetricsEmitter
 
-	if b.Config.DryRun {
-		log.WithFields(logrus.Fields{
-			"component": component,
-			"Asset":     fmt.Sprintf("%+v", asset),
-		}).Info("Dry run, asset configuration will be skipped.")
-		return nil
+	ctx, span := tracing.Tracer().Start(ctx, "butler.configureAsset")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("serial", asset.Serial),
+		attribute.String("vendor", asset.Vendor),
+	)
+
+	// --dryrun still logs in to the BMC and walks the same Apply() path as a
+	// real run - each resource case decides whether to write or call
+	// renderDryRun, via the dryRun flag threaded into its configurator. A
+	// blanket early return here would make dry-run mode skip the BMC login
+	// and the per-resource diff it's meant to show.
+	defer metric.MeasureRuntime([]string{"butler", "configure_runtime"}, time.Now())
+
+	// runReport accumulates a structured record of this run, emitted via
+	// b.ReportSink once the asset has been configured (or failed), so
+	// operators can audit what actually happened without grepping logs.
+	runReport := report.RunReport{
+		Asset:        *asset,
+		StartedAt:    time.Now(),
+		ConfigDigest: fmt.Sprintf("%x", sha256.Sum256(config)),
 	}
 
-	defer metric.MeasureRuntime([]string{"butler", "configure_runtime"}, time.Now())
+	defer func() {
+		runReport.Asset = *asset
+		runReport.FinishedAt = time.Now()
+		if err != nil {
+			runReport.Error = err.Error()
+		}
+
+		if b.ReportSink == nil {
+			return
+		}
+
+		if sinkErr := b.ReportSink.Emit(runReport); sinkErr != nil {
+			log.WithFields(logrus.Fields{
+				"component": component,
+				"Serial":    asset.Serial,
+				"Error":     sinkErr,
+			}).Warn("Unable to emit run report.")
+		}
+	}()
 
 	b.Log.WithFields(logrus.Fields{
 		"component": component,
@@ -40,17 +79,38 @@ func (b *Butler) configureAsset(config []byte, asset *asset.Asset) (err error) {
 		"IPAddress": asset.IPAddresses,
 	}).Debug("Connecting to asset.")
 
+	// ctx is already cancelled on shutdown (it descends from Runner's root
+	// context) and bounded by the per-message timeout, so an in-flight probe
+	// doesn't keep the asset's butler busy past its deadline.
 	bmcConn := bmclogin.Params{
 		IpAddresses:     asset.IPAddresses,
 		Credentials:     b.Config.Credentials,
 		CheckCredential: true,
 		Retries:         1,
 		StopChan:        b.StopChan,
+		ProbeObserver:   metrics.ObserveProbe,
 	}
 
 	//connect to the bmc/chassis bmc
+	loginCtx, loginSpan := tracing.Tracer().Start(ctx, "bmc.login")
+	loginSpan.SetAttributes(attribute.String("serial", asset.Serial))
+	bmcConn.Context = loginCtx
+
+	loginStart := time.Now()
 	client, loginInfo, err := bmcConn.Login()
 	if err != nil {
+		loginSpan.RecordError(err)
+	}
+	loginSpan.End()
+	runReport.AddStep(report.NewStep("login", loginStart, err))
+	if err != nil {
+		if err == bmclogin.ErrInterrupted {
+			log.WithFields(logrus.Fields{
+				"component": component,
+				"Serial":    asset.Serial,
+				"IPAddress": asset.IPAddresses,
+			}).Debug("Login interrupted by shutdown, skipping asset.")
+		}
 		return err
 	}
 
@@ -77,8 +137,10 @@ func (b *Butler) configureAsset(config []byte, asset *asset.Asset) (err error) {
 		}
 
 		// Apply configuration
-		c := configure.NewBmcConfigurator(bmc, asset, b.Config.Resources, renderedConfig, b.Config, b.StopChan, log)
+		applyStart := time.Now()
+		c := configure.NewBmcConfigurator(ctx, bmc, asset, b.Config.Resources, renderedConfig, b.Config, b.StopChan, log)
 		c.Apply()
+		runReport.AddStep(report.NewStep("apply_configuration", applyStart, nil))
 
 		bmc.Close()
 	case devices.BmcChassis:
@@ -101,7 +163,9 @@ func (b *Butler) configureAsset(config []byte, asset *asset.Asset) (err error) {
 		}
 
 		if renderedConfig.SetupChassis != nil {
+			setupStart := time.Now()
 			s := configure.NewBmcChassisSetup(
+				ctx,
 				chassis,
 				asset,
 				b.Config.Resources,
@@ -112,11 +176,14 @@ func (b *Butler) configureAsset(config []byte, asset *asset.Asset) (err error) {
 				b.Log,
 			)
 			s.Apply()
+			runReport.AddStep(report.NewStep("setup_chassis", setupStart, nil))
 		}
 
 		// Apply configuration
-		c := configure.NewBmcChassisConfigurator(chassis, asset, b.Config.Resources, renderedConfig, b.StopChan, log)
+		applyStart := time.Now()
+		c := configure.NewBmcChassisConfigurator(ctx, chassis, asset, b.Config.Resources, renderedConfig, b.StopChan, log)
 		c.Apply()
+		runReport.AddStep(report.NewStep("apply_configuration", applyStart, nil))
 
 		chassis.Close()
 	default:
@@ -127,5 +194,9 @@ func (b *Butler) configureAsset(config []byte, asset *asset.Asset) (err error) {
 		return errors.New("Unknown asset type")
 	}
 
+	if err == nil {
+		metrics.SetLastRun(asset.Serial, time.Now())
+	}
+
 	return err
 }
@@ -1,12 +1,18 @@
 package configure
 
 import (
+	"context"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+	"github.com/bmc-toolbox/bmcbutler/pkg/metrics"
+	"github.com/bmc-toolbox/bmcbutler/pkg/tracing"
 	"github.com/bmc-toolbox/bmclib/cfgresources"
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Cmc struct declares attributes required to apply configuration.
@@ -22,13 +28,18 @@ type Cmc struct {
 	vendor       string
 	hardwareType string
 	stopChan     <-chan struct{}
+	ctx          context.Context
+	dryRun       bool
+	dryRunFormat string
 }
 
 // NewCmcConfigurator returns a new configure struct to apply configuration.
-func NewCmcConfigurator(bmc devices.Cmc,
+func NewCmcConfigurator(ctx context.Context,
+	bmc devices.Cmc,
 	asset *asset.Asset,
 	resources []string,
-	config *cfgresources.ResourcesConfig,
+	resourcesConfig *cfgresources.ResourcesConfig,
+	cfg *config.Params,
 	stopChan <-chan struct{},
 	logger *logrus.Logger) *Cmc {
 
@@ -42,13 +53,16 @@ func NewCmcConfigurator(bmc devices.Cmc,
 		// devices.Cmc is type asserted to apply configuration,
 		// this is possible since devices.Bmc embeds the Configure interface.
 		configure:    bmc.(devices.Configure),
-		config:       config,
+		config:       resourcesConfig,
 		logger:       logger,
 		stopChan:     stopChan,
 		ip:           asset.IPAddress,
 		serial:       asset.Serial,
 		vendor:       asset.Vendor,
 		hardwareType: asset.HardwareType,
+		ctx:          ctx,
+		dryRun:       cfg.DryRun,
+		dryRunFormat: cfg.DryRunFormat,
 	}
 }
 
@@ -95,22 +109,48 @@ func (b *Cmc) Apply() { //nolint: gocyclo
 			break
 		}
 
+		resourceCtx, span := tracing.Tracer().Start(b.ctx, "configure.resource."+resource)
+		span.SetAttributes(
+			attribute.String("vendor", b.vendor),
+			attribute.String("serial", b.serial),
+			attribute.String("ip", b.ip),
+		)
+		traceID := tracing.TraceID(resourceCtx)
+
+		resourceStart := time.Now()
+
 		switch resource {
 		case "user":
 			if b.config.User != nil {
-				err = b.configure.User(b.config.User)
+				if b.dryRun {
+					b.renderDryRun(resource, b.config.User)
+				} else {
+					err = b.configure.User(b.config.User)
+				}
 			}
 		case "syslog":
 			if b.config.Syslog != nil {
-				err = b.configure.Syslog(b.config.Syslog)
+				if b.dryRun {
+					b.renderDryRun(resource, b.config.Syslog)
+				} else {
+					err = b.configure.Syslog(b.config.Syslog)
+				}
 			}
 		case "ntp":
 			if b.config.Ntp != nil {
-				err = b.configure.Ntp(b.config.Ntp)
+				if b.dryRun {
+					b.renderDryRun(resource, b.config.Ntp)
+				} else {
+					err = b.configure.Ntp(b.config.Ntp)
+				}
 			}
 		case "ldap":
 			if b.config.Ldap != nil {
-				err = b.configure.Ldap(b.config.Ldap)
+				if b.dryRun {
+					b.renderDryRun(resource, b.config.Ldap)
+				} else {
+					err = b.configure.Ldap(b.config.Ldap)
+				}
 			}
 		case "ldap_group":
 			if b.config.LdapGroups != nil && b.config.Ldap != nil {
@@ -126,15 +166,28 @@ func (b *Cmc) Apply() { //nolint: gocyclo
 						"Groups":       b.config.LdapGroups.Groups,
 					}).Warn("Trying to fetch more LDAP groups has failed.")
 				}
-				err = b.configure.LdapGroups(b.config.LdapGroups.Groups, b.config.Ldap)
+
+				if b.dryRun {
+					b.renderDryRun(resource, b.config.LdapGroups.Groups)
+				} else {
+					err = b.configure.LdapGroups(b.config.LdapGroups.Groups, b.config.Ldap)
+				}
 			}
 		case "license":
 			if b.config.License != nil {
-				err = b.configure.SetLicense(b.config.License)
+				if b.dryRun {
+					b.renderDryRun(resource, b.config.License)
+				} else {
+					err = b.configure.SetLicense(b.config.License)
+				}
 			}
 		case "network":
 			if b.config.Network != nil {
-				_, err = b.configure.Network(b.config.Network)
+				if b.dryRun {
+					b.renderDryRun(resource, b.config.Network)
+				} else {
+					_, err = b.configure.Network(b.config.Network)
+				}
 			}
 		default:
 			b.logger.WithFields(logrus.Fields{
@@ -142,8 +195,11 @@ func (b *Cmc) Apply() { //nolint: gocyclo
 			}).Warn("Unknown resource.")
 		}
 
+		metrics.ObserveResource(resource, b.vendor, b.hardwareType, err == nil, time.Since(resourceStart))
+
 		if err != nil {
 			failed = append(failed, resource)
+			span.RecordError(err)
 			b.logger.WithFields(logrus.Fields{
 				"resource":     resource,
 				"Vendor":       b.vendor,
@@ -151,17 +207,21 @@ func (b *Cmc) Apply() { //nolint: gocyclo
 				"Serial":       b.serial,
 				"IPAddress":    b.ip,
 				"Error":        err,
+				"TraceID":      traceID,
 			}).Warn("Resource configuration returned errors.")
 		} else {
 			success = append(success, resource)
 		}
 
+		span.End()
+
 		b.logger.WithFields(logrus.Fields{
 			"resource":     resource,
 			"Vendor":       b.vendor,
 			"HardwareType": b.hardwareType,
 			"Serial":       b.serial,
 			"IPAddress":    b.ip,
+			"TraceID":      traceID,
 		}).Trace("Resource configuration applied.")
 
 	}
@@ -188,3 +248,61 @@ func (b *Cmc) Apply() { //nolint: gocyclo
 		"applied":      strings.Join(success, ", "),
 	}).Info("CMC configuration actions successful.")
 }
+
+// ConfigReader is an optional interface a bmc/chassis driver may implement
+// to expose its current configuration for a resource, so dry-run mode can
+// render a diff against the desired state instead of just skipping the
+// write. Drivers that don't implement it still get a dry-run log entry,
+// just without a current-state comparison.
+type ConfigReader interface {
+	// ReadResource returns the BMC's current configuration for the named
+	// resource (e.g. "user", "syslog"), in the same shape devices.Configure
+	// accepts it in (e.g. *cfgresources.User, *cfgresources.Syslog).
+	ReadResource(resource string) (interface{}, error)
+}
+
+// renderDryRun logs what applying the given desired resource configuration
+// would change, without writing anything. If the underlying driver
+// implements ConfigReader, the diff is computed against its current state;
+// otherwise only the desired configuration is shown.
+func (b *Cmc) renderDryRun(resource string, desired interface{}) {
+	var current interface{}
+
+	if reader, ok := b.bmc.(ConfigReader); ok {
+		c, err := reader.ReadResource(resource)
+		if err != nil {
+			b.logger.WithFields(logrus.Fields{
+				"resource":  resource,
+				"Vendor":    b.vendor,
+				"Serial":    b.serial,
+				"IPAddress": b.ip,
+				"Error":     err,
+			}).Warn("Dry run: failed to read current resource state, showing desired configuration only.")
+		} else {
+			current = c
+		}
+	} else {
+		b.logger.WithFields(logrus.Fields{
+			"resource": resource,
+			"Vendor":   b.vendor,
+		}).Debug("Dry run: driver does not support reading current state, showing desired configuration only.")
+	}
+
+	diff, err := renderDiff(redactSecrets(current), redactSecrets(desired), b.dryRunFormat)
+	if err != nil {
+		b.logger.WithFields(logrus.Fields{
+			"resource": resource,
+			"Vendor":   b.vendor,
+			"Error":    err,
+		}).Warn("Dry run: failed to render diff.")
+		return
+	}
+
+	b.logger.WithFields(logrus.Fields{
+		"resource":  resource,
+		"Vendor":    b.vendor,
+		"Serial":    b.serial,
+		"IPAddress": b.ip,
+		"Diff":      diff,
+	}).Info("Dry run, resource configuration will be skipped.")
+}
@@ -0,0 +1,195 @@
+package configure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// secretFieldPattern matches JSON field names that hold a plaintext secret -
+// a BMC account password, an LDAP bind password, a token - case-insensitively.
+var secretFieldPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|bindpw|apikey|privatekey)`)
+
+// redactSecrets returns v's JSON representation with any field whose name
+// matches secretFieldPattern replaced with a fixed placeholder, so dry-run
+// diffs can be logged without leaking the values they carry. v is returned
+// unchanged if it's nil or doesn't round-trip through JSON as an object.
+func redactSecrets(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(out, &m); err != nil {
+		return v
+	}
+
+	redactMap(m)
+	return m
+}
+
+func redactMap(m map[string]interface{}) {
+	for k, val := range m {
+		if secretFieldPattern.MatchString(k) {
+			m[k] = "***REDACTED***"
+			continue
+		}
+		if nested, ok := val.(map[string]interface{}); ok {
+			redactMap(nested)
+		}
+	}
+}
+
+// renderDiff renders the difference between a resource's current and
+// desired configuration. format "json" renders a flat, field-level patch;
+// any other value (including the default "text") renders a unified-style
+// text diff of their JSON representations.
+func renderDiff(current, desired interface{}, format string) (string, error) {
+	if format == "json" {
+		currentFields, err := toFlatMap(current)
+		if err != nil {
+			return "", err
+		}
+
+		desiredFields, err := toFlatMap(desired)
+		if err != nil {
+			return "", err
+		}
+
+		return renderJSONPatch(currentFields, desiredFields)
+	}
+
+	return renderUnifiedDiff(current, desired)
+}
+
+// diffOp is a single field-level change, rendered in a JSON patch style.
+type diffOp struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+func renderJSONPatch(current, desired map[string]interface{}) (string, error) {
+	keys := make(map[string]bool, len(current)+len(desired))
+	for k := range current {
+		keys[k] = true
+	}
+	for k := range desired {
+		keys[k] = true
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	var ops []diffOp
+	for _, k := range sortedKeys {
+		from, to := current[k], desired[k]
+		if fmt.Sprintf("%v", from) == fmt.Sprintf("%v", to) {
+			continue
+		}
+		ops = append(ops, diffOp{Path: k, From: from, To: to})
+	}
+
+	out, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func renderUnifiedDiff(current, desired interface{}) (string, error) {
+	currentJSON, err := marshalIndented(current)
+	if err != nil {
+		return "", err
+	}
+
+	desiredJSON, err := marshalIndented(desired)
+	if err != nil {
+		return "", err
+	}
+
+	return unifiedLines(currentJSON, desiredJSON), nil
+}
+
+func marshalIndented(v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// unifiedLines renders a minimal unified-style diff between two blocks of
+// text, line by line. It's not a full LCS diff - for the small, flat
+// resource structs bmcbutler configures, a line-by-line comparison is
+// enough to review a change before it's applied.
+func unifiedLines(before, after string) string {
+	beforeLines := strings.Split(before, "\n")
+	afterLines := strings.Split(after, "\n")
+
+	max := len(beforeLines)
+	if len(afterLines) > max {
+		max = len(afterLines)
+	}
+
+	var buf bytes.Buffer
+	for i := 0; i < max; i++ {
+		var b, a string
+		if i < len(beforeLines) {
+			b = beforeLines[i]
+		}
+		if i < len(afterLines) {
+			a = afterLines[i]
+		}
+
+		if b == a {
+			buf.WriteString("  " + a + "\n")
+			continue
+		}
+		if b != "" {
+			buf.WriteString("- " + b + "\n")
+		}
+		if a != "" {
+			buf.WriteString("+ " + a + "\n")
+		}
+	}
+
+	return buf.String()
+}
+
+// toFlatMap marshals v to its JSON object representation, for field-level
+// comparison. A nil v (no current state available) flattens to an empty map.
+func toFlatMap(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(out, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
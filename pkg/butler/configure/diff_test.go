@@ -0,0 +1,47 @@
+package configure
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	type ldap struct {
+		BindDN       string `json:"bindDn"`
+		BindPassword string `json:"bindPassword"`
+	}
+
+	redacted := redactSecrets(ldap{BindDN: "cn=admin", BindPassword: "hunter2"}).(map[string]interface{})
+
+	if redacted["bindDn"] != "cn=admin" {
+		t.Errorf("bindDn = %v, want unchanged", redacted["bindDn"])
+	}
+	if redacted["bindPassword"] != "***REDACTED***" {
+		t.Errorf("bindPassword = %v, want redacted", redacted["bindPassword"])
+	}
+}
+
+func TestRedactSecretsNil(t *testing.T) {
+	if got := redactSecrets(nil); got != nil {
+		t.Errorf("redactSecrets(nil) = %v, want nil", got)
+	}
+}
+
+func TestRenderDiffJSONPatchRedactsBeforeLogging(t *testing.T) {
+	type user struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	current := redactSecrets(user{Username: "root", Password: "old"})
+	desired := redactSecrets(user{Username: "root", Password: "new"})
+
+	diff, err := renderDiff(current, desired, "json")
+	if err != nil {
+		t.Fatalf("renderDiff returned error: %s", err)
+	}
+
+	if strings.Contains(diff, "old") || strings.Contains(diff, "new") {
+		t.Errorf("renderDiff output leaked a plaintext password: %s", diff)
+	}
+}
@@ -1,13 +1,19 @@
 package butler
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+	"github.com/bmc-toolbox/bmcbutler/pkg/publisher"
+	"github.com/bmc-toolbox/bmcbutler/pkg/tracing"
+	"github.com/bmc-toolbox/bmclogin"
 	metrics "github.com/bmc-toolbox/gin-go-metrics"
 )
 
@@ -35,14 +41,25 @@ func (b *Butler) timeTrack(start time.Time, name string, asset *asset.Asset) {
 
 // msgHandler invokes the appropriate action based on msg attributes.
 // nolint: gocyclo
-func (b *Butler) msgHandler(msg Msg) {
-	// If an interrupt was received, return.
-	if b.interrupt {
+func (b *Butler) msgHandler(ctx context.Context, msg Msg) {
+	// If the per-message context has already expired (e.g. a shutdown was
+	// requested before this message was picked up), skip it.
+	if ctx.Err() != nil {
+		atomic.AddInt32(&b.Interrupted, 1)
 		return
 	}
 
 	component := "msgHandler"
 
+	ctx, span := tracing.Tracer().Start(ctx, "butler.msgHandler")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("serial", msg.Asset.Serial),
+		attribute.String("assetType", msg.Asset.Type),
+		attribute.String("vendor", msg.Asset.Vendor),
+		attribute.String("location", msg.Asset.Location),
+	)
+
 	metrics.IncrCounter([]string{"butler", "asset_recvd"}, 1)
 
 	// If an asset has no IPAddress, we can't do anything about it!
@@ -78,7 +95,21 @@ func (b *Butler) msgHandler(msg Msg) {
 
 	switch {
 	case msg.Asset.Execute:
-		err := b.executeCommand(msg.AssetExecute, &msg.Asset)
+		start := time.Now()
+		err := b.executeCommand(ctx, msg.AssetExecute, &msg.Asset)
+		result := publisher.AssetResult{
+			Asset:      msg.Asset,
+			Action:     "execute",
+			Success:    err == nil,
+			Duration:   time.Since(start),
+			DryRun:     b.Config.DryRun,
+			OccurredAt: start,
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		b.publish(result)
+
 		if err != nil {
 			b.Log.WithFields(logrus.Fields{
 				"component":    component,
@@ -93,6 +124,10 @@ func (b *Butler) msgHandler(msg Msg) {
 				"Vendor":       msg.Asset.Vendor, // At this point the vendor may or may not be known.
 			}).Warn("Execute action returned error.")
 			metrics.IncrCounter([]string{"butler", "execute_fail"}, 1)
+			if b.retryOrDeadLetter(msg, "execute", err) {
+				return
+			}
+			atomic.AddInt32(&b.Failed, 1)
 			return
 		}
 
@@ -105,9 +140,32 @@ func (b *Butler) msgHandler(msg Msg) {
 		}).Info("Execute action succeeded.")
 
 		metrics.IncrCounter([]string{"butler", "execute_success"}, 1)
+		atomic.AddInt32(&b.Completed, 1)
+		msg.Ack()
 		return
 	case msg.Asset.Configure:
-		err := b.configureAsset(msg.AssetConfig, &msg.Asset)
+		start := time.Now()
+		err := b.configureAsset(ctx, msg.AssetConfig, &msg.Asset)
+		if err != nil && err == bmclogin.ErrInterrupted {
+			metrics.IncrCounter([]string{"butler", "configure_interrupted"}, 1)
+			atomic.AddInt32(&b.Interrupted, 1)
+			msg.Nack()
+			return
+		}
+
+		result := publisher.AssetResult{
+			Asset:      msg.Asset,
+			Action:     "configure",
+			Success:    err == nil,
+			Duration:   time.Since(start),
+			DryRun:     b.Config.DryRun,
+			OccurredAt: start,
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		b.publish(result)
+
 		if err != nil {
 			b.Log.WithFields(logrus.Fields{
 				"component":    component,
@@ -123,6 +181,10 @@ func (b *Butler) msgHandler(msg Msg) {
 			}).Warn("Configure action returned error.")
 
 			metrics.IncrCounter([]string{"butler", "configure_fail"}, 1)
+			if b.retryOrDeadLetter(msg, "configure", err) {
+				return
+			}
+			atomic.AddInt32(&b.Failed, 1)
 			return
 		}
 
@@ -139,6 +201,8 @@ func (b *Butler) msgHandler(msg Msg) {
 		}).Info("Configure action succeeded.")
 
 		metrics.IncrCounter([]string{"butler", "configure_success"}, 1)
+		atomic.AddInt32(&b.Completed, 1)
+		msg.Ack()
 		return
 	default:
 		b.Log.WithFields(logrus.Fields{
@@ -0,0 +1,191 @@
+package butler
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/deadletter"
+)
+
+// Defaults applied to Config.Retry fields left unset.
+const (
+	defaultMaxAttempts  = 3
+	defaultInitialDelay = 5 * time.Second
+	defaultMultiplier   = 2.0
+	defaultMaxDelay     = 5 * time.Minute
+)
+
+// builtinTransientErrors are substrings, matched case-insensitively against
+// an error's message, that bmcbutler always treats as transient - a dropped
+// connection, a timeout, an HTTP 5xx/429 - regardless of Config.Retry's own
+// TransientErrors list.
+var builtinTransientErrors = []string{
+	"connection refused",
+	"connection reset",
+	"i/o timeout",
+	"eof",
+	"too many requests",
+	"429",
+	"500",
+	"502",
+	"503",
+	"504",
+}
+
+// isTransient reports whether err looks like a transient BMC failure (one
+// likely to succeed on a later attempt) rather than a permanent one, such as
+// bad credentials or unsupported hardware.
+func (b *Butler) isTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	for _, substr := range builtinTransientErrors {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+
+	for _, substr := range b.Config.Retry.TransientErrors {
+		if strings.Contains(msg, strings.ToLower(substr)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maxAttempts returns Config.Retry.MaxAttempts, defaulting to
+// defaultMaxAttempts when unset.
+func (b *Butler) maxAttempts() int {
+	if b.Config.Retry.MaxAttempts > 0 {
+		return b.Config.Retry.MaxAttempts
+	}
+
+	return defaultMaxAttempts
+}
+
+// backoff returns the delay to wait before the given attempt (1-indexed) is
+// retried: Config.Retry.InitialDelay scaled by Multiplier per prior attempt,
+// capped at MaxDelay, and randomized between 0 and the result if Jitter is set.
+func (b *Butler) backoff(attempt int) time.Duration {
+	cfg := b.Config.Retry
+
+	initial := cfg.InitialDelay
+	if initial <= 0 {
+		initial = defaultInitialDelay
+	}
+
+	multiplier := cfg.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultMultiplier
+	}
+
+	maxDelay := cfg.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	delay := float64(initial)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+	}
+
+	d := time.Duration(delay)
+	if d > maxDelay {
+		d = maxDelay
+	}
+
+	if cfg.Jitter {
+		d = time.Duration(rand.Int63n(int64(d) + 1))
+	}
+
+	return d
+}
+
+// retryOrDeadLetter is called by msgHandler when action ("configure" or
+// "execute") failed on msg.Asset with err. If RetryChan is set, err looks
+// transient and msg hasn't exhausted Config.Retry's MaxAttempts, it
+// schedules a retry and returns true. Otherwise it sends msg to the
+// dead-letter sink and returns false, leaving msgHandler's usual Failed
+// bookkeeping to its caller.
+func (b *Butler) retryOrDeadLetter(msg Msg, action string, err error) bool {
+	msg.Attempt++
+
+	if b.RetryChan != nil && b.isTransient(err) && msg.Attempt < b.maxAttempts() {
+		b.scheduleRetry(msg, b.backoff(msg.Attempt))
+		return true
+	}
+
+	b.deadLetter(msg, action, err)
+	return false
+}
+
+// scheduleRetry waits delay, then re-enqueues msg onto RetryChan, unless
+// b.ctx or StopChan fire first. b.ctx is the Runner's long-lived root
+// context, not the per-message context msgHandler was called with - that
+// one is cancelled the instant msgHandler returns, which is immediately
+// after this goroutine is spawned, and would cancel the backoff before it
+// ever had a chance to fire.
+func (b *Butler) scheduleRetry(msg Msg, delay time.Duration) {
+	if b.RetryChan == nil {
+		b.deadLetter(msg, "", errors.New("no RetryChan configured, dropping asset instead of retrying"))
+		return
+	}
+
+	b.SyncWG.Add(1)
+	go func() {
+		defer b.SyncWG.Done()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-b.ctx.Done():
+			return
+		case <-b.StopChan:
+			return
+		}
+
+		select {
+		case b.RetryChan <- msg:
+		case <-b.StopChan:
+		}
+	}()
+}
+
+// deadLetter Nacks msg (so a message-driven source like Kafka doesn't
+// consider it successfully handled) and, if a dead-letter sink is
+// configured, records why the asset was given up on.
+func (b *Butler) deadLetter(msg Msg, action string, err error) {
+	msg.Nack()
+
+	if b.DeadLetter == nil {
+		return
+	}
+
+	entry := deadletter.Entry{
+		Asset:      msg.Asset,
+		Action:     action,
+		Attempts:   msg.Attempt,
+		OccurredAt: time.Now(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if sendErr := b.DeadLetter.Send(entry); sendErr != nil {
+		b.Log.WithFields(logrus.Fields{
+			"component": "deadLetter",
+			"Serial":    msg.Asset.Serial,
+			"Error":     sendErr,
+		}).Warn("Unable to send asset to dead-letter sink.")
+	}
+}
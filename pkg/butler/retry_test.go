@@ -0,0 +1,71 @@
+package butler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+func TestIsTransient(t *testing.T) {
+	b := &Butler{Config: &config.Params{
+		Retry: config.RetryConfig{TransientErrors: []string{"rate limited"}},
+	}}
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"builtin substring", errors.New("dial tcp: connection refused"), true},
+		{"builtin http status", errors.New("unexpected status 503"), true},
+		{"case insensitive builtin", errors.New("Connection Reset by peer"), true},
+		{"configured substring", errors.New("BMC: rate limited, try again"), true},
+		{"permanent error", errors.New("invalid credentials"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := b.isTransient(tc.err); got != tc.want {
+				t.Errorf("isTransient(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	b := &Butler{Config: &config.Params{
+		Retry: config.RetryConfig{
+			InitialDelay: time.Second,
+			Multiplier:   2,
+			MaxDelay:     5 * time.Second,
+		},
+	}}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second}, // capped at MaxDelay
+		{5, 5 * time.Second},
+	}
+
+	for _, tc := range cases {
+		if got := b.backoff(tc.attempt); got != tc.want {
+			t.Errorf("backoff(%d) = %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestBackoffDefaults(t *testing.T) {
+	b := &Butler{Config: &config.Params{}}
+
+	if got := b.backoff(1); got != defaultInitialDelay {
+		t.Errorf("backoff(1) with unset config = %v, want %v", got, defaultInitialDelay)
+	}
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"io/ioutil"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// FilterParams holds the configure/setup/execute-related host filter CLI args.
+type FilterParams struct {
+	All     bool
+	Chassis bool
+	Servers bool
+	Serials string
+	Ips     string
+}
+
+// GraphiteConfig holds the graphite metrics backend configuration.
+type GraphiteConfig struct {
+	Host          string `yaml:"host"`
+	Port          int    `yaml:"port"`
+	Prefix        string `yaml:"prefix"`
+	FlushInterval int    `yaml:"flushInterval"`
+}
+
+// MetricsConfig holds the metrics client configuration.
+type MetricsConfig struct {
+	Client   string         `yaml:"client"`
+	Graphite GraphiteConfig `yaml:"graphite"`
+}
+
+// DoraConfig holds the Dora inventory source configuration.
+type DoraConfig struct {
+	URL string `yaml:"url"`
+
+	// MaxRetries is the number of retries on a retryable (5xx/network)
+	// error before giving up. Defaults to 5 if unset.
+	MaxRetries int `yaml:"maxRetries"`
+	// InitialInterval is the backoff before the first retry. Defaults to
+	// 500ms if unset.
+	InitialInterval time.Duration `yaml:"initialInterval"`
+	// MaxInterval caps the exponential backoff between retries. Defaults
+	// to 30s if unset.
+	MaxInterval time.Duration `yaml:"maxInterval"`
+}
+
+// RedfishConfig holds the Redfish inventory source configuration.
+type RedfishConfig struct {
+	// Scheme is the URL scheme used to reach each Redfish service ("https" by default).
+	Scheme string `yaml:"scheme"`
+	// Hosts is the seed list of Redfish service hosts/IPs to walk.
+	Hosts []string `yaml:"hosts"`
+	// CIDRs is a list of CIDR ranges to sweep for Redfish services, in addition to Hosts.
+	CIDRs []string `yaml:"cidrs"`
+}
+
+// ConsulConfig holds the Consul inventory source configuration.
+type ConsulConfig struct {
+	// Address is the Consul HTTP API address, e.g. "http://localhost:8500".
+	Address string `yaml:"address"`
+	// Service is the Consul service name tagged as a BMC. Defaults to "bmc".
+	Service string `yaml:"service"`
+	// Token is the Consul ACL token to use, if ACLs are enabled.
+	Token string `yaml:"token"`
+	// LocationTag is a tag prefix (e.g. "site") whose value becomes the
+	// asset's Location. Falls back to the entry's Datacenter if unset or
+	// not present on an entry.
+	LocationTag string `yaml:"locationTag"`
+	// Blocking, when set, keeps long-polling the Consul catalog for
+	// changes instead of reading it once, so bmcbutler can run as a daemon
+	// that reacts to newly provisioned nodes.
+	Blocking bool `yaml:"blocking"`
+	// WaitTime caps how long a blocking query waits for a catalog change.
+	// Defaults to 5m if unset.
+	WaitTime time.Duration `yaml:"waitTime"`
+}
+
+// KafkaSASLConfig holds the SASL credentials for the Kafka inventory source.
+type KafkaSASLConfig struct {
+	Mechanism string `yaml:"mechanism"` // e.g. "PLAIN", "SCRAM-SHA-512"
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+}
+
+// KafkaConfig holds the Kafka inventory source configuration.
+type KafkaConfig struct {
+	// Brokers is the list of seed broker addresses, e.g. "kafka1:9092".
+	Brokers []string `yaml:"brokers"`
+	// ConsumerGroup is the consumer group bmcbutler joins to read Topic.
+	ConsumerGroup string `yaml:"consumerGroup"`
+	// Topic is the Kafka topic carrying asset messages.
+	Topic string `yaml:"topic"`
+	// TLS enables a TLS connection to the brokers.
+	TLS bool `yaml:"tls"`
+	// SASL holds the SASL credentials, if the brokers require them.
+	SASL KafkaSASLConfig `yaml:"sasl"`
+	// InitialOffset is where a new consumer group starts reading from:
+	// "oldest" (default) or "newest".
+	InitialOffset string `yaml:"initialOffset"`
+	// BatchSize sets the default number of message bytes fetched per
+	// request (sarama's Consumer.Fetch.Default). Defaults to the Kafka
+	// client's own default if unset.
+	BatchSize int `yaml:"batchSize"`
+}
+
+// InventoryConfig holds the inventory source configuration.
+type InventoryConfig struct {
+	Source  string        `yaml:"source"`
+	Dora    DoraConfig    `yaml:"dora"`
+	Redfish RedfishConfig `yaml:"redfish"`
+	Consul  ConsulConfig  `yaml:"consul"`
+	Kafka   KafkaConfig   `yaml:"kafka"`
+}
+
+// LemurSignerConfig holds the Lemur cert signer configuration.
+type LemurSignerConfig struct {
+	Key string `yaml:"key"`
+}
+
+// CertSignerConfig holds the cert signer configuration.
+type CertSignerConfig struct {
+	LemurSigner LemurSignerConfig `yaml:"lemurSigner"`
+}
+
+// VaultConfig holds the Vault secrets backend configuration.
+type VaultConfig struct {
+	Address string `yaml:"address"`
+	Token   string `yaml:"token"`
+}
+
+// WebhookPublisherConfig holds the webhook/HTTP publisher configuration.
+type WebhookPublisherConfig struct {
+	URL string `yaml:"url"`
+	// HMACSecret, when set, signs each POST body with HMAC-SHA256 so the
+	// receiver can verify it came from bmcbutler.
+	HMACSecret string `yaml:"hmacSecret"`
+}
+
+// KafkaPublisherConfig holds the Kafka publisher configuration.
+type KafkaPublisherConfig struct {
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+}
+
+// ServerServiceConfig holds the serverservice-style CMDB publisher configuration.
+type ServerServiceConfig struct {
+	// URL is the serverservice base URL, e.g. "https://serverservice.example.com".
+	URL   string `yaml:"url"`
+	Token string `yaml:"token"`
+}
+
+// PublishersConfig holds the AssetResult publisher configuration.
+type PublishersConfig struct {
+	// Enabled lists which publishers to activate: "webhook", "kafka", "serverservice".
+	Enabled       []string               `yaml:"enabled"`
+	Webhook       WebhookPublisherConfig `yaml:"webhook"`
+	Kafka         KafkaPublisherConfig   `yaml:"kafka"`
+	ServerService ServerServiceConfig    `yaml:"serverservice"`
+}
+
+// RetryConfig holds the per-asset retry policy applied when configureAsset
+// or executeCommand returns a transient error.
+type RetryConfig struct {
+	// MaxAttempts caps the total number of attempts made on an asset, the
+	// first one included, before it's sent to the dead-letter sink.
+	// Defaults to 3 if unset.
+	MaxAttempts int `yaml:"maxAttempts"`
+	// InitialDelay is the backoff before the first retry. Defaults to 5s if unset.
+	InitialDelay time.Duration `yaml:"initialDelay"`
+	// Multiplier scales the delay after each attempt. Defaults to 2 if unset.
+	Multiplier float64 `yaml:"multiplier"`
+	// MaxDelay caps the exponential backoff between retries. Defaults to 5m if unset.
+	MaxDelay time.Duration `yaml:"maxDelay"`
+	// Jitter, when set, randomizes each delay between 0 and the computed
+	// backoff, spreading out retries of assets that failed at the same time.
+	Jitter bool `yaml:"jitter"`
+	// TransientErrors is a list of substrings matched (case-insensitively)
+	// against an error's message to classify it as transient and therefore
+	// retryable, in addition to bmcbutler's built-in classifications
+	// (connection refused, timeouts, 5xx, throttling).
+	TransientErrors []string `yaml:"transientErrors"`
+}
+
+// DeadLetterConfig holds the dead-letter sink configuration, used for assets
+// that exhaust their retry budget or fail with a permanent error.
+type DeadLetterConfig struct {
+	// Sink selects where dead-lettered assets are sent: "file" or "kafka".
+	// Dead-lettering is disabled when unset.
+	Sink     string               `yaml:"sink"`
+	FilePath string               `yaml:"filePath"`
+	Kafka    KafkaPublisherConfig `yaml:"kafka"`
+}
+
+// ReportConfig holds the structured per-asset run report configuration.
+type ReportConfig struct {
+	// Sink selects where run reports are emitted: "stdout" (default JSON
+	// lines), "file", or "webhook".
+	Sink string `yaml:"sink"`
+	// FilePath is the file run reports are appended to, used when Sink is "file".
+	FilePath string `yaml:"filePath"`
+	// WebhookURL is the endpoint run reports are POSTed to, used when Sink is "webhook".
+	WebhookURL string `yaml:"webhookUrl"`
+}
+
+// Params holds bmcbutler runtime configuration,
+// populated from the config file and overridden by CLI flags.
+type Params struct {
+	Debug            bool
+	Trace            bool
+	DryRun           bool
+	DryRunFormat     string
+	IgnoreLocation   bool
+	SecretsFromVault bool
+	ButlersToSpawn   int
+	CfgFile          string
+	Locations        []string             `yaml:"locations"`
+	Resources        []string             `yaml:"resources"`
+	Credentials      []map[string]string  `yaml:"credentials"`
+	FilterParams     *FilterParams
+	Metrics          MetricsConfig    `yaml:"metrics"`
+	Inventory        InventoryConfig  `yaml:"inventory"`
+	CertSigner       CertSignerConfig `yaml:"certSigner"`
+	Vault            *VaultConfig     `yaml:"vault"`
+	Report           ReportConfig     `yaml:"report"`
+	Publishers       PublishersConfig `yaml:"publishers"`
+	Retry            RetryConfig      `yaml:"retry"`
+	DeadLetter       DeadLetterConfig `yaml:"deadLetter"`
+
+	// LogFormat selects the logrus formatter: "json" (default) or "text".
+	LogFormat string
+	// LogOutput is the log sink: "stdout" (default), "stderr", or a file path.
+	LogOutput string
+	// LogLevel overrides --debug/--trace when set ("panic", "fatal", "error", "warn", "info", "debug", "trace").
+	LogLevel string
+	// NoSyslog disables the local syslog hook when set.
+	NoSyslog bool
+
+	// MetricsListen is the listen address for the Prometheus /metrics and
+	// /healthz endpoints, e.g. ":9090". Left empty, the endpoint is disabled.
+	MetricsListen string
+
+	// OtlpEndpoint is the OTLP/gRPC collector address to export traces to,
+	// e.g. "localhost:4317". Left empty, tracing is disabled.
+	OtlpEndpoint string
+
+	// ShutdownGrace is how long in-flight butlers get to wind down after a
+	// SIGINT/SIGTERM before they're hard-killed.
+	ShutdownGrace time.Duration
+
+	// PerAssetTimeout bounds how long a single asset's Configure/Execute
+	// action is given to finish, via a context.Context deadline. Defaults
+	// to 10m if unset.
+	PerAssetTimeout time.Duration
+}
+
+// Load reads the given YAML config file into the Params struct.
+func (p *Params) Load(cfgFile string) {
+	data, err := ioutil.ReadFile(cfgFile)
+	if err != nil {
+		return
+	}
+
+	yaml.Unmarshal(data, p)
+}
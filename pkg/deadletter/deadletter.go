@@ -0,0 +1,20 @@
+// Package deadletter holds assets that exhausted their retry budget or
+// failed with a permanent error, so operators can inspect and reprocess
+// them later instead of losing the failure outright.
+package deadletter
+
+import (
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+)
+
+// Entry is a structured record of an asset action that was given up on,
+// with enough context for an operator to reprocess it.
+type Entry struct {
+	Asset      asset.Asset `json:"asset"`
+	Action     string      `json:"action"` // "configure" or "execute"
+	Error      string      `json:"error"`
+	Attempts   int         `json:"attempts"`
+	OccurredAt time.Time   `json:"occurredAt"`
+}
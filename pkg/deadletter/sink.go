@@ -0,0 +1,110 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// Sink is implemented by every dead-letter destination, so msgHandler can
+// give up on an asset without caring where it ends up.
+type Sink interface {
+	Send(entry Entry) error
+	// Close releases any resources the sink holds (connections, producers).
+	// Called once by cmd's post() after commandWG.Wait().
+	Close() error
+}
+
+// FileSink appends each Entry as a line of JSON to a file.
+type FileSink struct {
+	Path string
+}
+
+// Send implements Sink.
+func (f FileSink) Send(entry Entry) error {
+	out, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(out, '\n'))
+	return err
+}
+
+// Close implements Sink. The file sink holds no resources between Send calls.
+func (f FileSink) Close() error {
+	return nil
+}
+
+// KafkaSink produces each Entry as a JSON message to a Kafka topic.
+type KafkaSink struct {
+	Topic    string
+	producer sarama.SyncProducer
+}
+
+// NewKafkaSink builds a KafkaSink from cfg.
+func NewKafkaSink(cfg config.KafkaPublisherConfig) (*KafkaSink, error) {
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(cfg.Brokers, saramaCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaSink{Topic: cfg.Topic, producer: producer}, nil
+}
+
+// Send implements Sink.
+func (k *KafkaSink) Send(entry Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: k.Topic,
+		Key:   sarama.StringEncoder(entry.Asset.Serial),
+		Value: sarama.ByteEncoder(body),
+	})
+
+	return err
+}
+
+// Close implements Sink.
+func (k *KafkaSink) Close() error {
+	return k.producer.Close()
+}
+
+// NewSink builds the configured Sink from cfg, or nil if dead-lettering is
+// disabled (cfg.Sink unset).
+func NewSink(cfg config.DeadLetterConfig) (Sink, error) {
+	switch cfg.Sink {
+	case "":
+		return nil, nil
+	case "file":
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("deadLetter.filePath must be set for the file sink")
+		}
+
+		return FileSink{Path: cfg.FilePath}, nil
+	case "kafka":
+		if len(cfg.Kafka.Brokers) == 0 || cfg.Kafka.Topic == "" {
+			return nil, fmt.Errorf("deadLetter.kafka.brokers and deadLetter.kafka.topic must be set for the kafka sink")
+		}
+
+		return NewKafkaSink(cfg.Kafka)
+	default:
+		return nil, fmt.Errorf("unknown deadLetter sink: %s", cfg.Sink)
+	}
+}
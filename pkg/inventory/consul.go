@@ -0,0 +1,229 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+	metrics "github.com/bmc-toolbox/gin-go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+func init() {
+	Register("consul", func(ctx context.Context, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, stopChan <-chan struct{}) Source {
+		return &Consul{Ctx: ctx, Config: cfg, Log: log, AssetsChan: assetsChan, StopChan: stopChan}
+	})
+}
+
+// Consul struct holds attributes required to retrieve assets tagged as
+// BMCs from a Consul service catalog, and pass them to the butlers.
+type Consul struct {
+	// Ctx bounds each catalog query, including a long-polling blocking
+	// query, so it's abandoned promptly on shutdown.
+	Ctx        context.Context
+	Log        *logrus.Logger
+	AssetsChan chan<- []asset.Asset
+	Config     *config.Params
+	StopChan   <-chan struct{}
+}
+
+// consulCatalogEntry is the subset of a Consul catalog service entry
+// bmcbutler cares about.
+type consulCatalogEntry struct {
+	Node           string
+	Address        string
+	Datacenter     string
+	ServiceID      string
+	ServiceAddress string
+	ServiceTags    []string
+}
+
+// consulErrorBackoff is how long AssetIter waits before retrying a blocking query
+// that failed, so an unreachable Consul doesn't get hammered in a tight loop.
+const consulErrorBackoff = 5 * time.Second
+
+// Name identifies this inventory source as used in Config.Inventory.Source.
+func (c *Consul) Name() string {
+	return "consul"
+}
+
+// Validate checks Consul has everything it needs in cfg to run.
+func (c *Consul) Validate(cfg *config.Params) error {
+	if cfg.Inventory.Consul.Address == "" {
+		return errors.New("consul inventory source requires inventory.consul.address to be set")
+	}
+
+	return nil
+}
+
+// service returns the configured Consul service name, defaulting to "bmc".
+func (c *Consul) service() string {
+	if c.Config.Inventory.Consul.Service != "" {
+		return c.Config.Inventory.Consul.Service
+	}
+
+	return "bmc"
+}
+
+// waitTime returns the configured blocking query wait, defaulting to 5m.
+func (c *Consul) waitTime() time.Duration {
+	if c.Config.Inventory.Consul.WaitTime > 0 {
+		return c.Config.Inventory.Consul.WaitTime
+	}
+
+	return 5 * time.Minute
+}
+
+// AssetRetrieve returns the asset iterator method.
+func (c *Consul) AssetRetrieve() func() {
+	return c.AssetIter
+}
+
+// queryCatalog fetches the catalog entries for the configured service. When
+// Blocking is set, it issues a Consul blocking query against index,
+// returning once Consul reports a catalog change or the wait elapses. It
+// returns the entries and the X-Consul-Index to resume from on the next call.
+func (c *Consul) queryCatalog(index string) ([]consulCatalogEntry, string, error) {
+	queryURL := fmt.Sprintf("%s/v1/catalog/service/%s", c.Config.Inventory.Consul.Address, c.service())
+
+	if c.Config.Inventory.Consul.Blocking {
+		queryURL += fmt.Sprintf("?index=%s&wait=%s", index, c.waitTime())
+	}
+
+	req, err := http.NewRequestWithContext(c.Ctx, "GET", queryURL, nil)
+	if err != nil {
+		return nil, index, err
+	}
+
+	if c.Config.Inventory.Consul.Token != "" {
+		req.Header.Set("X-Consul-Token", c.Config.Inventory.Consul.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, index, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, index, fmt.Errorf("consul returned status %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, index, err
+	}
+
+	var entries []consulCatalogEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, index, err
+	}
+
+	return entries, resp.Header.Get("X-Consul-Index"), nil
+}
+
+// toAsset converts a Consul catalog entry into an asset.Asset, deriving
+// Vendor/Type/Serial from its tags and Location from the configured
+// location tag, falling back to the entry's Datacenter.
+func (c *Consul) toAsset(entry consulCatalogEntry) asset.Asset {
+	ip := entry.ServiceAddress
+	if ip == "" {
+		ip = entry.Address
+	}
+
+	a := asset.Asset{
+		IPAddress:   ip,
+		IPAddresses: []string{ip},
+		Serial:      entry.ServiceID,
+		Type:        "server",
+		Location:    entry.Datacenter,
+	}
+
+	locationTag := c.Config.Inventory.Consul.LocationTag
+
+	for _, tag := range entry.ServiceTags {
+		switch {
+		case strings.HasPrefix(tag, "vendor="):
+			a.Vendor = strings.TrimPrefix(tag, "vendor=")
+		case strings.HasPrefix(tag, "type="):
+			a.Type = strings.TrimPrefix(tag, "type=")
+		case strings.HasPrefix(tag, "serial="):
+			a.Serial = strings.TrimPrefix(tag, "serial=")
+		case locationTag != "" && strings.HasPrefix(tag, locationTag+"="):
+			a.Location = strings.TrimPrefix(tag, locationTag+"=")
+		}
+	}
+
+	return a
+}
+
+// AssetIter reads the Consul catalog for the configured service and sends
+// matching assets over the inventory channel. If Blocking is set, it keeps
+// long-polling Consul for catalog changes - newly provisioned nodes tagged
+// with the BMC service are picked up without restarting bmcbutler - until
+// StopChan fires.
+func (c *Consul) AssetIter() {
+	component := "inventory"
+	log := c.Log
+	defer close(c.AssetsChan)
+
+	index := "0"
+
+	for {
+		entries, nextIndex, err := c.queryCatalog(index)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"component": component,
+				"service":   c.service(),
+				"Error":     err,
+			}).Warn("Unable to query Consul catalog for BMC services.")
+
+			if c.Config.Inventory.Consul.Blocking {
+				select {
+				case <-c.StopChan:
+					log.WithFields(logrus.Fields{
+						"component": component,
+					}).Debug("Consul catalog watch stopped.")
+					return
+				case <-time.After(consulErrorBackoff):
+				}
+			}
+		} else {
+			assets := make([]asset.Asset, 0, len(entries))
+			for _, entry := range entries {
+				assets = append(assets, c.toAsset(entry))
+			}
+
+			metrics.IncrCounter(
+				[]string{"inventory", "assets_returned_consul"},
+				int64(len(assets)),
+			)
+
+			if len(assets) > 0 {
+				c.AssetsChan <- assets
+			}
+
+			index = nextIndex
+		}
+
+		if !c.Config.Inventory.Consul.Blocking {
+			return
+		}
+
+		select {
+		case <-c.StopChan:
+			log.WithFields(logrus.Fields{
+				"component": component,
+			}).Debug("Consul catalog watch stopped.")
+			return
+		default:
+		}
+	}
+}
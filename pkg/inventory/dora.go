@@ -15,11 +15,15 @@
 package inventory
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
 	"github.com/bmc-toolbox/bmcbutler/pkg/config"
@@ -27,9 +31,18 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+func init() {
+	Register("dora", func(ctx context.Context, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, _ <-chan struct{}) Source {
+		return &Dora{Ctx: ctx, Config: cfg, Log: log, BatchSize: 10, AssetsChan: assetsChan}
+	})
+}
+
 // Dora struct holds attributes required to retrieve assets from Dora,
 // and pass them to the butlers.
 type Dora struct {
+	// Ctx bounds every HTTP request Dora makes, so a sweep in progress is
+	// abandoned promptly on shutdown instead of running to completion.
+	Ctx             context.Context
 	Log             *logrus.Logger
 	BatchSize       int
 	AssetsChan      chan<- []asset.Asset
@@ -64,6 +77,71 @@ type DoraAsset struct {
 	Links DoraLinks       `json:"links"`
 }
 
+// doraGet performs an HTTP GET against Dora, retrying with exponential
+// backoff and jitter on network errors and 5xx responses. 4xx responses
+// are terminal - Dora won't return a different answer for the same
+// request, so retrying would just waste a sweep's time budget.
+func (d *Dora) doraGet(queryURL string) (*http.Response, error) {
+	maxRetries := d.Config.Inventory.Dora.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 5
+	}
+
+	interval := d.Config.Inventory.Dora.InitialInterval
+	if interval == 0 {
+		interval = 500 * time.Millisecond
+	}
+
+	maxInterval := d.Config.Inventory.Dora.MaxInterval
+	if maxInterval == 0 {
+		maxInterval = 30 * time.Second
+	}
+
+	var lastErr error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		req, err := http.NewRequestWithContext(d.Ctx, "GET", queryURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil && resp.StatusCode < 500 {
+			return resp, nil
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("dora returned status %d", resp.StatusCode)
+			resp.Body.Close()
+		}
+
+		if attempt == maxRetries {
+			break
+		}
+
+		metrics.IncrCounter([]string{"inventory", "dora", "retries"}, 1)
+
+		d.Log.WithFields(logrus.Fields{
+			"component": "inventory",
+			"url":       queryURL,
+			"attempt":   attempt + 1,
+			"Error":     lastErr,
+		}).Warn("Dora request failed, retrying.")
+
+		// full jitter: sleep somewhere between 0 and the current interval.
+		time.Sleep(time.Duration(rand.Int63n(int64(interval))))
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+
+	return nil, lastErr
+}
+
 // for a list of assets, update its location value
 func (d *Dora) setLocation(doraInventoryAssets []asset.Asset) (err error) {
 	component := "inventory"
@@ -80,12 +158,22 @@ func (d *Dora) setLocation(doraInventoryAssets []asset.Asset) (err error) {
 	}
 
 	queryURL += strings.Join(ips, ",")
-	resp, err := http.Get(queryURL)
-	if err != nil || resp.StatusCode != 200 {
+	resp, err := d.doraGet(queryURL)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"component": component,
+			"url":       queryURL,
+			"Error":     err,
+		}).Warn("Unable to query Dora for IP location info.")
+		return err
+	}
+
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		err = fmt.Errorf("dora returned status %d", resp.StatusCode)
 		log.WithFields(logrus.Fields{
 			"component":  component,
 			"url":        queryURL,
-			"Error":      err,
 			"StatusCode": resp.StatusCode,
 		}).Warn("Unable to query Dora for IP location info.")
 		return err
@@ -117,6 +205,20 @@ func (d *Dora) setLocation(doraInventoryAssets []asset.Asset) (err error) {
 	return err
 }
 
+// Name identifies this inventory source as used in Config.Inventory.Source.
+func (d *Dora) Name() string {
+	return "dora"
+}
+
+// Validate checks Dora has everything it needs in cfg to run.
+func (d *Dora) Validate(cfg *config.Params) error {
+	if cfg.Inventory.Dora.URL == "" {
+		return errors.New("dora inventory source requires inventory.dora.url to be set")
+	}
+
+	return nil
+}
+
 func (d *Dora) AssetRetrieve() func() {
 	// Setup the asset types we want to retrieve data for.
 	switch {
@@ -166,24 +268,26 @@ func (d *Dora) AssetIterBySerial() {
 		queryURL += strings.ToLower(serials)
 		assets := make([]asset.Asset, 0)
 
-		resp, err := http.Get(queryURL)
+		resp, err := d.doraGet(queryURL)
 		if err != nil {
 			log.WithFields(logrus.Fields{
 				"component": component,
 				"url":       queryURL,
 				"Error":     err,
-			}).Fatal("Failed to query dora for serial(s).")
+			}).Warn("Failed to query dora for serial(s), skipping asset type.")
+			continue
 		}
 
 		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
 			log.WithFields(logrus.Fields{
 				"component": component,
 				"url":       queryURL,
 				"Error":     err,
-			}).Fatal("Failed to query dora for serial(s).")
+			}).Warn("Failed to query dora for serial(s), skipping asset type.")
+			continue
 		}
-		resp.Body.Close()
 
 		var doraAssets DoraAsset
 		err = json.Unmarshal(body, &doraAssets)
@@ -192,7 +296,8 @@ func (d *Dora) AssetIterBySerial() {
 				"component": component,
 				"url":       queryURL,
 				"Error":     err,
-			}).Fatal("Unable to unmarshal data returned from dora.")
+			}).Warn("Unable to unmarshal data returned from dora, skipping asset type.")
+			continue
 		}
 
 		if len(doraAssets.Data) == 0 {
@@ -248,6 +353,7 @@ func (d *Dora) AssetIter() {
 
 	log := d.Log
 
+assetTypeLoop:
 	for _, assetType := range d.FilterAssetType {
 		var path string
 
@@ -264,24 +370,29 @@ func (d *Dora) AssetIter() {
 		for {
 			assets := make([]asset.Asset, 0)
 
-			resp, err := http.Get(queryURL)
-			if err != nil || resp.StatusCode != 200 {
+			resp, err := d.doraGet(queryURL)
+			if err == nil && resp.StatusCode != 200 {
+				err = fmt.Errorf("dora returned status %d", resp.StatusCode)
+			}
+			if err != nil {
 				log.WithFields(logrus.Fields{
 					"component": component,
 					"url":       queryURL,
 					"Error":     err,
-				}).Fatal("Error querying Dora for assets.")
+				}).Warn("Error querying Dora for assets, skipping asset type.")
+				continue assetTypeLoop
 			}
 
 			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
 			if err != nil {
 				log.WithFields(logrus.Fields{
 					"component": component,
 					"url":       queryURL,
 					"Error":     err,
-				}).Fatal("Error querying Dora for assets.")
+				}).Warn("Error querying Dora for assets, skipping asset type.")
+				continue assetTypeLoop
 			}
-			resp.Body.Close()
 
 			var doraAssets DoraAsset
 			err = json.Unmarshal(body, &doraAssets)
@@ -290,7 +401,8 @@ func (d *Dora) AssetIter() {
 					"component": component,
 					"url":       queryURL,
 					"Error":     err,
-				}).Fatal("Error unmarshaling data returned from Dora.")
+				}).Warn("Error unmarshaling data returned from Dora, skipping asset type.")
+				continue assetTypeLoop
 			}
 
 			metrics.IncrCounter(
@@ -0,0 +1,242 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"github.com/sirupsen/logrus"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+	metrics "github.com/bmc-toolbox/gin-go-metrics"
+)
+
+func init() {
+	Register("kafka", func(ctx context.Context, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, stopChan <-chan struct{}) Source {
+		return &Kafka{Ctx: ctx, Config: cfg, Log: log, AssetsChan: assetsChan, StopChan: stopChan, Decoder: JSONDecoder{}}
+	})
+}
+
+// Decoder turns a single inventory message's key/value into zero or more
+// assets. JSONDecoder is the default; a Protobuf or Avro decoder can be
+// plugged in by setting Kafka.Decoder.
+type Decoder interface {
+	Decode(key, value []byte) ([]asset.Asset, error)
+}
+
+// JSONDecoder decodes a message value as either a single asset.Asset or a
+// JSON array of asset.Asset.
+type JSONDecoder struct{}
+
+// Decode implements Decoder.
+func (JSONDecoder) Decode(key, value []byte) ([]asset.Asset, error) {
+	var assets []asset.Asset
+	if err := json.Unmarshal(value, &assets); err == nil {
+		return assets, nil
+	}
+
+	var a asset.Asset
+	if err := json.Unmarshal(value, &a); err != nil {
+		return nil, fmt.Errorf("decode kafka message: %s", err)
+	}
+
+	return []asset.Asset{a}, nil
+}
+
+// Kafka is an inventory Source that subscribes to a topic and decodes each
+// message into one or more assets, pushed onto AssetsChan for butlers to
+// process. A message's offset is only committed once msgHandler acknowledges
+// the resulting asset(s) via asset.Asset.Ack/Nack, so a failed
+// Configure/Execute action can be replayed on the next rebalance/restart.
+type Kafka struct {
+	// Ctx is the run's root context; it's joined with StopChan below to
+	// bound the consumer group session.
+	Ctx        context.Context
+	Log        *logrus.Logger
+	AssetsChan chan<- []asset.Asset
+	Config     *config.Params
+	StopChan   <-chan struct{}
+	// Decoder turns each message's key/value into assets. Defaults to
+	// JSONDecoder when constructed via the registry.
+	Decoder Decoder
+}
+
+// Name identifies this inventory source as used in Config.Inventory.Source.
+func (k *Kafka) Name() string {
+	return "kafka"
+}
+
+// Validate checks Kafka has everything it needs in cfg to run.
+func (k *Kafka) Validate(cfg *config.Params) error {
+	if len(cfg.Inventory.Kafka.Brokers) == 0 {
+		return errors.New("kafka inventory source requires inventory.kafka.brokers to be set")
+	}
+
+	if cfg.Inventory.Kafka.Topic == "" {
+		return errors.New("kafka inventory source requires inventory.kafka.topic to be set")
+	}
+
+	return nil
+}
+
+// AssetRetrieve returns the asset iterator method.
+func (k *Kafka) AssetRetrieve() func() {
+	return k.AssetIter
+}
+
+// saramaConfig builds the sarama consumer configuration from
+// Config.Inventory.Kafka.
+func (k *Kafka) saramaConfig() *sarama.Config {
+	kafkaCfg := k.Config.Inventory.Kafka
+
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_1_0_0
+
+	if kafkaCfg.InitialOffset == "newest" {
+		cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	} else {
+		cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	}
+
+	if kafkaCfg.BatchSize > 0 {
+		cfg.Consumer.Fetch.Default = int32(kafkaCfg.BatchSize)
+	}
+
+	if kafkaCfg.TLS {
+		cfg.Net.TLS.Enable = true
+	}
+
+	if kafkaCfg.SASL.Username != "" {
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.User = kafkaCfg.SASL.Username
+		cfg.Net.SASL.Password = kafkaCfg.SASL.Password
+		cfg.Net.SASL.Mechanism = sarama.SASLMechanism(kafkaCfg.SASL.Mechanism)
+	}
+
+	return cfg
+}
+
+// AssetIter joins the configured consumer group and rebalances/consumes the
+// configured topic until StopChan fires, decoding and forwarding assets onto
+// AssetsChan.
+func (k *Kafka) AssetIter() {
+	component := "inventory.kafka"
+	log := k.Log
+	defer close(k.AssetsChan)
+
+	kafkaCfg := k.Config.Inventory.Kafka
+
+	group, err := sarama.NewConsumerGroup(kafkaCfg.Brokers, kafkaCfg.ConsumerGroup, k.saramaConfig())
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"component": component,
+			"Error":     err,
+		}).Warn("Unable to set up Kafka consumer group.")
+		return
+	}
+	defer group.Close()
+
+	ctx, cancel := context.WithCancel(k.Ctx)
+	defer cancel()
+	go func() {
+		<-k.StopChan
+		cancel()
+	}()
+
+	handler := &kafkaHandler{kafka: k}
+
+	for ctx.Err() == nil {
+		if err := group.Consume(ctx, []string{kafkaCfg.Topic}, handler); err != nil && ctx.Err() == nil {
+			log.WithFields(logrus.Fields{
+				"component": component,
+				"Error":     err,
+			}).Warn("Kafka consume attempt returned error, retrying.")
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"component": component,
+	}).Debug("Kafka consumer stopped.")
+}
+
+// kafkaHandler implements sarama.ConsumerGroupHandler. It decodes each
+// claimed message and blocks on the resulting asset(s)' Ack/Nack before
+// marking (or skipping) the message, so offsets track msgHandler's outcome
+// rather than delivery.
+type kafkaHandler struct {
+	kafka *Kafka
+}
+
+// Setup implements sarama.ConsumerGroupHandler.
+func (h *kafkaHandler) Setup(sarama.ConsumerGroupSession) error { return nil }
+
+// Cleanup implements sarama.ConsumerGroupHandler.
+func (h *kafkaHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+// ConsumeClaim implements sarama.ConsumerGroupHandler.
+func (h *kafkaHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			h.deliver(session, msg)
+		case <-h.kafka.StopChan:
+			return nil
+		}
+	}
+}
+
+// deliver decodes a single Kafka message, forwards its asset(s) onto
+// AssetsChan wired with Ack/Nack callbacks, and waits for every asset to be
+// acknowledged before marking the message - or skips marking it on a Nack or
+// shutdown, so it's redelivered on the next rebalance.
+func (h *kafkaHandler) deliver(session sarama.ConsumerGroupSession, msg *sarama.ConsumerMessage) {
+	component := "inventory.kafka"
+	log := h.kafka.Log
+
+	assets, err := h.kafka.Decoder.Decode(msg.Key, msg.Value)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"component": component,
+			"Error":     err,
+			"Topic":     msg.Topic,
+			"Partition": msg.Partition,
+			"Offset":    msg.Offset,
+		}).Warn("Unable to decode Kafka message, skipping.")
+		session.MarkMessage(msg, "")
+		return
+	}
+
+	acked := make(chan bool, len(assets))
+	for i := range assets {
+		assets[i].Ack = func() { acked <- true }
+		assets[i].Nack = func() { acked <- false }
+	}
+
+	metrics.IncrCounter([]string{"inventory", "assets_returned_kafka"}, int64(len(assets)))
+
+	select {
+	case h.kafka.AssetsChan <- assets:
+	case <-h.kafka.StopChan:
+		return
+	}
+
+	for range assets {
+		select {
+		case ok := <-acked:
+			if !ok {
+				return
+			}
+		case <-h.kafka.StopChan:
+			return
+		}
+	}
+
+	session.MarkMessage(msg, "")
+}
@@ -0,0 +1,382 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+	metrics "github.com/bmc-toolbox/gin-go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// redfishRequestTimeout bounds a single Redfish HTTP request, so a CIDR
+// sweep doesn't hang indefinitely on a host that accepts the connection but
+// never responds.
+const redfishRequestTimeout = 30 * time.Second
+
+var redfishHTTPClient = &http.Client{Timeout: redfishRequestTimeout}
+
+func init() {
+	Register("redfish", func(ctx context.Context, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, _ <-chan struct{}) Source {
+		return &Redfish{Ctx: ctx, Config: cfg, Log: log, AssetsChan: assetsChan}
+	})
+}
+
+// Redfish struct holds attributes required to retrieve assets from a
+// vendor-neutral DMTF Redfish service, and pass them to the butlers.
+type Redfish struct {
+	// Ctx bounds every HTTP request made while walking a Redfish service, so
+	// a sweep in progress is abandoned promptly on shutdown.
+	Ctx             context.Context
+	Log             *logrus.Logger
+	AssetsChan      chan<- []asset.Asset
+	Config          *config.Params
+	FilterAssetType []string
+}
+
+// redfishCollection is the common shape of a Redfish collection resource
+// (Systems, Chassis, Managers): its members and an optional next page link.
+type redfishCollection struct {
+	Members []struct {
+		ODataID string `json:"@odata.id"`
+	} `json:"Members"`
+	NextLink string `json:"Members@odata.nextLink"`
+}
+
+// redfishComputerSystem is the subset of a Redfish ComputerSystem resource
+// bmcbutler cares about.
+type redfishComputerSystem struct {
+	SerialNumber string `json:"SerialNumber"`
+	Manufacturer string `json:"Manufacturer"`
+	Model        string `json:"Model"`
+}
+
+// redfishChassis is the subset of a Redfish Chassis resource bmcbutler
+// cares about.
+type redfishChassis struct {
+	SerialNumber string `json:"SerialNumber"`
+	Manufacturer string `json:"Manufacturer"`
+	Model        string `json:"Model"`
+}
+
+// redfishManager is the subset of a Redfish Manager resource bmcbutler
+// cares about - just enough to find its EthernetInterfaces collection.
+type redfishManager struct {
+	EthernetInterfaces struct {
+		ODataID string `json:"@odata.id"`
+	} `json:"EthernetInterfaces"`
+}
+
+// redfishEthernetInterface is the subset of a Redfish EthernetInterface
+// resource bmcbutler cares about.
+type redfishEthernetInterface struct {
+	IPv4Addresses []struct {
+		Address string `json:"Address"`
+	} `json:"IPv4Addresses"`
+}
+
+// Name identifies this inventory source as used in Config.Inventory.Source.
+func (r *Redfish) Name() string {
+	return "redfish"
+}
+
+// Validate checks Redfish has everything it needs in cfg to run.
+func (r *Redfish) Validate(cfg *config.Params) error {
+	if len(cfg.Inventory.Redfish.Hosts) == 0 && len(cfg.Inventory.Redfish.CIDRs) == 0 {
+		return errors.New("redfish inventory source requires inventory.redfish.hosts or inventory.redfish.cidrs to be set")
+	}
+
+	return nil
+}
+
+// AssetRetrieve sets up the asset types to be retrieved based on the
+// FilterParams, and returns the asset iterator method.
+func (r *Redfish) AssetRetrieve() func() {
+	switch {
+	case r.Config.FilterParams.Chassis:
+		r.FilterAssetType = append(r.FilterAssetType, "chassis")
+	case r.Config.FilterParams.Servers:
+		r.FilterAssetType = append(r.FilterAssetType, "server")
+	default:
+		r.FilterAssetType = []string{"chassis", "server"}
+	}
+
+	return r.AssetIter
+}
+
+// seedHosts returns the set of Redfish service hosts to walk: the
+// configured seed list, plus any hosts turned up by sweeping the configured
+// CIDRs.
+func (r *Redfish) seedHosts() []string {
+	component := "inventory"
+	log := r.Log
+
+	hosts := append([]string{}, r.Config.Inventory.Redfish.Hosts...)
+
+	for _, cidr := range r.Config.Inventory.Redfish.CIDRs {
+		ip, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"component": component,
+				"cidr":      cidr,
+				"Error":     err,
+			}).Warn("Unable to parse Redfish CIDR, skipping.")
+			continue
+		}
+
+		for ip := ip.Mask(ipnet.Mask); ipnet.Contains(ip); incIP(ip) {
+			hosts = append(hosts, ip.String())
+		}
+	}
+
+	return hosts
+}
+
+// incIP increments an IP address in place, used to walk a CIDR range.
+func incIP(ip net.IP) {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			break
+		}
+	}
+}
+
+// scheme returns the configured Redfish URL scheme, defaulting to https.
+func (r *Redfish) scheme() string {
+	if r.Config.Inventory.Redfish.Scheme != "" {
+		return r.Config.Inventory.Redfish.Scheme
+	}
+
+	return "https"
+}
+
+// get fetches and unmarshals the Redfish resource at path on host.
+func (r *Redfish) get(host, path string, v interface{}) error {
+	url := fmt.Sprintf("%s://%s%s", r.scheme(), host, path)
+
+	req, err := http.NewRequestWithContext(r.Ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := redfishHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// walkCollection follows a Redfish collection's Members and
+// Members@odata.nextLink, invoking visit with each member's @odata.id.
+func (r *Redfish) walkCollection(host, path string, visit func(memberPath string)) error {
+	for path != "" {
+		var collection redfishCollection
+		if err := r.get(host, path, &collection); err != nil {
+			return err
+		}
+
+		for _, member := range collection.Members {
+			visit(member.ODataID)
+		}
+
+		path = collection.NextLink
+	}
+
+	return nil
+}
+
+// managerIPs walks host's Managers collection and its EthernetInterfaces,
+// returning any configured IPv4 addresses. Used to enrich an asset's
+// IPAddresses beyond the Redfish service endpoint it was discovered on.
+func (r *Redfish) managerIPs(host string) []string {
+	component := "inventory"
+	log := r.Log
+
+	var ips []string
+
+	err := r.walkCollection(host, "/redfish/v1/Managers", func(managerPath string) {
+		var manager redfishManager
+		if err := r.get(host, managerPath, &manager); err != nil || manager.EthernetInterfaces.ODataID == "" {
+			return
+		}
+
+		err := r.walkCollection(host, manager.EthernetInterfaces.ODataID, func(ifacePath string) {
+			var iface redfishEthernetInterface
+			if err := r.get(host, ifacePath, &iface); err != nil {
+				return
+			}
+
+			for _, addr := range iface.IPv4Addresses {
+				if addr.Address != "" {
+					ips = append(ips, addr.Address)
+				}
+			}
+		})
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"component": component,
+				"host":      host,
+				"Error":     err,
+			}).Debug("Unable to walk Redfish manager ethernet interfaces.")
+		}
+	})
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"component": component,
+			"host":      host,
+			"Error":     err,
+		}).Debug("Unable to walk Redfish Managers collection.")
+	}
+
+	return ips
+}
+
+// resolveAsset fetches a Systems or Chassis member resource and converts it
+// to an asset.Asset, falling back to host/ips when a field isn't populated.
+func (r *Redfish) resolveAsset(host, memberPath, assetType string, ips []string) (asset.Asset, error) {
+	a := asset.Asset{
+		IPAddress:   host,
+		IPAddresses: []string{host},
+		Type:        assetType,
+	}
+
+	if len(ips) > 0 {
+		a.IPAddress = ips[0]
+		a.IPAddresses = ips
+	}
+
+	switch assetType {
+	case "chassis":
+		var chassis redfishChassis
+		if err := r.get(host, memberPath, &chassis); err != nil {
+			return a, err
+		}
+
+		a.Serial = chassis.SerialNumber
+		a.Vendor = chassis.Manufacturer
+		a.Model = chassis.Model
+	default:
+		var system redfishComputerSystem
+		if err := r.get(host, memberPath, &system); err != nil {
+			return a, err
+		}
+
+		a.Serial = system.SerialNumber
+		a.Vendor = system.Manufacturer
+		a.Model = system.Model
+	}
+
+	return a, nil
+}
+
+// splitSerials splits a comma-separated, possibly empty serial filter into
+// its lower-cased elements, for an exact (not substring) match against a
+// discovered asset's serial.
+func splitSerials(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	parts := strings.Split(raw, ",")
+	serials := make([]string, 0, len(parts))
+	for _, p := range parts {
+		serials = append(serials, strings.ToLower(strings.TrimSpace(p)))
+	}
+
+	return serials
+}
+
+// matchesSerial reports whether serial exactly matches one of filter's
+// entries, case-insensitively.
+func matchesSerial(filter []string, serial string) bool {
+	serial = strings.ToLower(serial)
+	for _, s := range filter {
+		if s == serial {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssetIter walks each seed host's Systems and Chassis collections,
+// filters by serial (if given) and FilterAssetType, and sends the resulting
+// assets over the inventory channel.
+func (r *Redfish) AssetIter() {
+	component := "inventory"
+	log := r.Log
+	defer close(r.AssetsChan)
+
+	serialFilter := splitSerials(r.Config.FilterParams.Serials)
+
+	for _, host := range r.seedHosts() {
+		assets := make([]asset.Asset, 0)
+		ips := r.managerIPs(host)
+
+		for _, assetType := range r.FilterAssetType {
+			collectionPath := "/redfish/v1/Systems"
+			if assetType == "chassis" {
+				collectionPath = "/redfish/v1/Chassis"
+			}
+
+			err := r.walkCollection(host, collectionPath, func(memberPath string) {
+				a, err := r.resolveAsset(host, memberPath, assetType, ips)
+				if err != nil {
+					log.WithFields(logrus.Fields{
+						"component": component,
+						"host":      host,
+						"path":      memberPath,
+						"Error":     err,
+					}).Warn("Unable to retrieve Redfish resource.")
+					return
+				}
+
+				if len(serialFilter) > 0 && !matchesSerial(serialFilter, a.Serial) {
+					return
+				}
+
+				assets = append(assets, a)
+			})
+			if err != nil {
+				log.WithFields(logrus.Fields{
+					"component": component,
+					"host":      host,
+					"path":      collectionPath,
+					"Error":     err,
+				}).Warn("Unable to walk Redfish collection, skipping collection.")
+				continue
+			}
+		}
+
+		if len(assets) == 0 {
+			continue
+		}
+
+		metrics.IncrCounter(
+			[]string{"inventory", "assets_returned_redfish"},
+			int64(len(assets)),
+		)
+
+		r.AssetsChan <- assets
+	}
+}
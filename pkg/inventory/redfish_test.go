@@ -0,0 +1,50 @@
+package inventory
+
+import "testing"
+
+func TestSplitSerials(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want []string
+	}{
+		{"", nil},
+		{"ABC123", []string{"abc123"}},
+		{"ABC123, def456", []string{"abc123", "def456"}},
+	}
+
+	for _, tc := range cases {
+		got := splitSerials(tc.raw)
+		if len(got) != len(tc.want) {
+			t.Errorf("splitSerials(%q) = %v, want %v", tc.raw, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("splitSerials(%q) = %v, want %v", tc.raw, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMatchesSerial(t *testing.T) {
+	filter := splitSerials("ABC123,DEF456")
+
+	cases := []struct {
+		serial string
+		want   bool
+	}{
+		{"abc123", true},
+		{"ABC123", true},
+		{"def456", true},
+		{"123", false},      // substring of abc123, must not match
+		{"4abc123", false},  // superstring of abc123, must not match
+		{"xyz789", false},
+	}
+
+	for _, tc := range cases {
+		if got := matchesSerial(filter, tc.serial); got != tc.want {
+			t.Errorf("matchesSerial(%v, %q) = %v, want %v", filter, tc.serial, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// Source is implemented by every inventory provider (Dora, Redfish, and any
+// out-of-tree provider), so bmcbutler can instantiate and drive them by
+// name without a type switch in the main entrypoint.
+type Source interface {
+	// Name returns the inventory source's name, as used in
+	// Config.Inventory.Source (e.g. "dora", "redfish").
+	Name() string
+	// Validate checks the source has everything it needs in cfg to run,
+	// returning a descriptive error if not.
+	Validate(cfg *config.Params) error
+	// AssetRetrieve returns the iterator function that sends assets over
+	// the provider's AssetsChan and closes it once done.
+	AssetRetrieve() func()
+}
+
+// Factory builds a new, unconfigured Source instance wired up to write to
+// assetsChan. ctx is the run's root context - long-running fetches (an HTTP
+// request, an exec) should carry it so they're cancelled promptly on
+// shutdown instead of being abandoned. stopChan is for providers that run as
+// a long-lived watch (e.g. Consul blocking queries) and need to know when to
+// stop; one-shot providers can ignore it. Each provider registers its
+// Factory in init() via Register.
+type Factory func(ctx context.Context, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, stopChan <-chan struct{}) Source
+
+var registry = map[string]Factory{}
+
+// Register adds a provider factory to the registry under name. Built-in
+// providers call this from their own init(); out-of-tree providers can call
+// it before New/NewMultiSource is invoked to plug in without touching main.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New instantiates and validates the named inventory source. name is looked
+// up in the registry first; providers not yet migrated onto it (csv, enc,
+// iplist) are built directly as a fallback, so New is the single place that
+// knows how to turn a name into a Source - both the single-source path in
+// cmd and NewMultiSource go through it, and neither has to duplicate the
+// other's fallback.
+func New(ctx context.Context, name string, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, stopChan <-chan struct{}) (Source, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return newLegacy(name, cfg, log, assetsChan, stopChan)
+	}
+
+	source := factory(ctx, cfg, log, assetsChan, stopChan)
+	if err := source.Validate(cfg); err != nil {
+		return nil, fmt.Errorf("inventory source %q: %s", name, err)
+	}
+
+	return source, nil
+}
+
+// newLegacy builds a provider that predates the registry and has no Factory
+// registered for it. These still satisfy the Source interface, just via
+// their own hand-rolled constructors instead of init()-time Register calls.
+func newLegacy(name string, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, stopChan <-chan struct{}) (Source, error) {
+	switch name {
+	case "enc":
+		return &Enc{Config: cfg, Log: log, BatchSize: 10, AssetsChan: assetsChan, StopChan: stopChan}, nil
+	case "csv":
+		return &Csv{Config: cfg, Log: log, AssetsChan: assetsChan}, nil
+	case "iplist":
+		return &IPList{Channel: assetsChan, Config: cfg, BatchSize: 1, Log: log}, nil
+	default:
+		return nil, fmt.Errorf("unknown inventory source: %s", name)
+	}
+}
+
+// fannedSource pairs a Source with the private channel it was constructed
+// with, so MultiSource can fan it into the shared AssetsChan.
+type fannedSource struct {
+	source  Source
+	private chan []asset.Asset
+}
+
+// MultiSource fans several providers into a single AssetsChan, so e.g. a
+// Redfish sweep and a local CSV file can be combined in one run.
+type MultiSource struct {
+	out     chan<- []asset.Asset
+	sources []fannedSource
+}
+
+// NewMultiSource builds a MultiSource from the named inventory sources.
+// Each source gets its own private channel; MultiSource fans them into
+// assetsChan and is the only one that closes it.
+func NewMultiSource(ctx context.Context, names []string, cfg *config.Params, log *logrus.Logger, assetsChan chan<- []asset.Asset, stopChan <-chan struct{}) (*MultiSource, error) {
+	m := &MultiSource{out: assetsChan}
+
+	for _, name := range names {
+		private := make(chan []asset.Asset)
+
+		source, err := New(ctx, name, cfg, log, private, stopChan)
+		if err != nil {
+			return nil, err
+		}
+
+		m.sources = append(m.sources, fannedSource{source: source, private: private})
+	}
+
+	return m, nil
+}
+
+// Name identifies a MultiSource by the names of the sources fanned into it.
+func (m *MultiSource) Name() string {
+	names := make([]string, 0, len(m.sources))
+	for _, fs := range m.sources {
+		names = append(names, fs.source.Name())
+	}
+
+	return "multi:" + joinNames(names)
+}
+
+func joinNames(names []string) string {
+	out := ""
+	for i, name := range names {
+		if i > 0 {
+			out += "+"
+		}
+		out += name
+	}
+
+	return out
+}
+
+// Validate is a no-op: each fanned-in source was already validated by New
+// when NewMultiSource built it.
+func (m *MultiSource) Validate(cfg *config.Params) error {
+	return nil
+}
+
+// AssetRetrieve runs every fanned-in source concurrently. Each source
+// writes to and closes its own private channel; this forwards every
+// private channel onto the shared AssetsChan, closing it once all sources
+// have finished.
+func (m *MultiSource) AssetRetrieve() func() {
+	return func() {
+		defer close(m.out)
+
+		var wg sync.WaitGroup
+		for _, fs := range m.sources {
+			wg.Add(1)
+			go func(fs fannedSource) {
+				defer wg.Done()
+
+				retrieve := fs.source.AssetRetrieve()
+				go retrieve()
+
+				for assets := range fs.private {
+					m.out <- assets
+				}
+			}(fs)
+		}
+
+		wg.Wait()
+	}
+}
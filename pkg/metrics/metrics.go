@@ -0,0 +1,125 @@
+// Package metrics exposes bmcbutler's internal counters, histograms and
+// gauges as a Prometheus endpoint, alongside a liveness probe.
+// It is additive to the existing gin-go-metrics/Graphite reporting set up
+// in pkg/cmd - this package is only active when --metrics-listen is set.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	probeAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bmcbutler",
+		Subsystem: "discover",
+		Name:      "probe_attempts_total",
+		Help:      "Number of BMC vendor probes attempted, by probeID and outcome.",
+	}, []string{"probe_id", "outcome"})
+
+	probeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "bmcbutler",
+		Subsystem: "discover",
+		Name:      "probe_duration_seconds",
+		Help:      "Latency of BMC vendor probes, by probeID.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"probe_id"})
+
+	resourceApply = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bmcbutler",
+		Subsystem: "configure",
+		Name:      "resource_apply_total",
+		Help:      "Number of per-resource configuration applies, by resource, vendor, hardware type and outcome.",
+	}, []string{"resource", "vendor", "hardware_type", "outcome"})
+
+	resourceApplyDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "bmcbutler",
+		Subsystem: "configure",
+		Name:      "resource_apply_duration_seconds",
+		Help:      "Latency of per-resource configuration applies, by resource, vendor and hardware type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"resource", "vendor", "hardware_type"})
+
+	activeButlers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "bmcbutler",
+		Name:      "active_butlers",
+		Help:      "Number of butlers currently processing an asset.",
+	})
+
+	queuedAssets = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "bmcbutler",
+		Name:      "queued_assets",
+		Help:      "Number of assets waiting in the butler worker pool queue.",
+	})
+
+	lastRunTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "bmcbutler",
+		Name:      "last_successful_run_timestamp",
+		Help:      "Unix timestamp of the last successful run, by asset serial.",
+	}, []string{"serial"})
+)
+
+// ObserveProbe records the outcome and latency of a single vendor probe
+// carried out by discover.ScanAndConnect.
+func ObserveProbe(probeID, outcome string, duration time.Duration) {
+	probeAttempts.WithLabelValues(probeID, outcome).Inc()
+	probeDuration.WithLabelValues(probeID).Observe(duration.Seconds())
+}
+
+// ObserveResource records the outcome and latency of applying a single
+// resource in configure.Cmc.Apply (or its sibling server configurator).
+func ObserveResource(resource, vendor, hardwareType string, success bool, duration time.Duration) {
+	outcome := "success"
+	if !success {
+		outcome = "failure"
+	}
+
+	resourceApply.WithLabelValues(resource, vendor, hardwareType, outcome).Inc()
+	resourceApplyDuration.WithLabelValues(resource, vendor, hardwareType).Observe(duration.Seconds())
+}
+
+// SetActiveButlers sets the gauge tracking butlers currently processing an asset.
+func SetActiveButlers(n int) {
+	activeButlers.Set(float64(n))
+}
+
+// SetQueuedAssets sets the gauge tracking assets waiting to be picked up by a butler.
+func SetQueuedAssets(n int) {
+	queuedAssets.Set(float64(n))
+}
+
+// SetLastRun records the time of the last successful run for the given asset serial.
+func SetLastRun(serial string, t time.Time) {
+	lastRunTimestamp.WithLabelValues(serial).Set(float64(t.Unix()))
+}
+
+// Serve starts the Prometheus /metrics and /healthz endpoints on listenAddress.
+// It blocks, so callers should invoke it in its own goroutine.
+func Serve(listenAddress string, log *logrus.Logger) {
+	component := "metrics"
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	log.WithFields(logrus.Fields{
+		"component": component,
+		"address":   listenAddress,
+	}).Info("Metrics endpoint listening.")
+
+	if err := http.ListenAndServe(listenAddress, mux); err != nil {
+		log.WithFields(logrus.Fields{
+			"component": component,
+			"address":   listenAddress,
+			"Error":     err,
+		}).Error("Metrics endpoint stopped.")
+	}
+}
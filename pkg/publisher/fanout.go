@@ -0,0 +1,89 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// queueSize bounds each publisher's backlog, so a slow or stuck publisher
+// can't block Configure/Execute processing for the others.
+const queueSize = 100
+
+// worker pairs a Publisher with its own bounded queue and goroutine.
+type worker struct {
+	publisher Publisher
+	queue     chan AssetResult
+}
+
+// Fanout fans a single AssetResult out to every configured Publisher, each
+// running in its own goroutine with its own bounded queue.
+type Fanout struct {
+	log     *logrus.Logger
+	workers []*worker
+	wg      sync.WaitGroup
+}
+
+// NewFanout starts a worker goroutine per publisher.
+func NewFanout(log *logrus.Logger, publishers []Publisher) *Fanout {
+	f := &Fanout{log: log}
+
+	for _, p := range publishers {
+		w := &worker{publisher: p, queue: make(chan AssetResult, queueSize)}
+		f.workers = append(f.workers, w)
+
+		f.wg.Add(1)
+		go f.run(w)
+	}
+
+	return f
+}
+
+func (f *Fanout) run(w *worker) {
+	defer f.wg.Done()
+
+	for result := range w.queue {
+		if err := w.publisher.Publish(context.Background(), result); err != nil {
+			f.log.WithFields(logrus.Fields{
+				"component": "publisher",
+				"Error":     err,
+			}).Warn("Unable to publish asset result.")
+		}
+	}
+}
+
+// Publish enqueues result on every worker's queue. If a worker's queue is
+// full, its result is dropped (with a warning) rather than blocking the
+// caller.
+func (f *Fanout) Publish(result AssetResult) {
+	for _, w := range f.workers {
+		select {
+		case w.queue <- result:
+		default:
+			f.log.WithFields(logrus.Fields{
+				"component": "publisher",
+				"Serial":    result.Asset.Serial,
+			}).Warn("Publisher queue full, dropping asset result.")
+		}
+	}
+}
+
+// Close stops every worker once its queue drains, then closes each
+// underlying Publisher.
+func (f *Fanout) Close() error {
+	for _, w := range f.workers {
+		close(w.queue)
+	}
+
+	f.wg.Wait()
+
+	var firstErr error
+	for _, w := range f.workers {
+		if err := w.publisher.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
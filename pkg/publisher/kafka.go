@@ -0,0 +1,50 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// KafkaPublisher produces each AssetResult as a JSON message to a Kafka topic.
+type KafkaPublisher struct {
+	Topic    string
+	producer sarama.SyncProducer
+}
+
+// NewKafkaPublisher builds a KafkaPublisher from cfg.
+func NewKafkaPublisher(cfg config.KafkaPublisherConfig) (*KafkaPublisher, error) {
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(cfg.Brokers, saramaCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaPublisher{Topic: cfg.Topic, producer: producer}, nil
+}
+
+// Publish implements Publisher.
+func (k *KafkaPublisher) Publish(ctx context.Context, result AssetResult) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: k.Topic,
+		Key:   sarama.StringEncoder(result.Asset.Serial),
+		Value: sarama.ByteEncoder(body),
+	})
+
+	return err
+}
+
+// Close implements Publisher.
+func (k *KafkaPublisher) Close() error {
+	return k.producer.Close()
+}
@@ -0,0 +1,32 @@
+package publisher
+
+import (
+	"fmt"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// New builds the publishers named in cfg.Publishers.Enabled.
+func New(cfg *config.Params) ([]Publisher, error) {
+	var publishers []Publisher
+
+	for _, name := range cfg.Publishers.Enabled {
+		switch name {
+		case "webhook":
+			publishers = append(publishers, NewWebhookPublisher(cfg.Publishers.Webhook))
+		case "kafka":
+			kafkaPublisher, err := NewKafkaPublisher(cfg.Publishers.Kafka)
+			if err != nil {
+				return nil, fmt.Errorf("publisher %q: %s", name, err)
+			}
+
+			publishers = append(publishers, kafkaPublisher)
+		case "serverservice":
+			publishers = append(publishers, NewServerServicePublisher(cfg.Publishers.ServerService))
+		default:
+			return nil, fmt.Errorf("unknown publisher: %s", name)
+		}
+	}
+
+	return publishers, nil
+}
@@ -0,0 +1,32 @@
+// Package publisher fans out the outcome of each Configure/Execute action to
+// one or more external sinks - a webhook, a Kafka topic, a CMDB - so systems
+// beyond bmcbutler's own logs/metrics can react to what happened.
+package publisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+)
+
+// AssetResult is a structured record of a single Configure/Execute action's
+// outcome.
+type AssetResult struct {
+	Asset      asset.Asset   `json:"asset"`
+	Action     string        `json:"action"` // "configure" or "execute"
+	Success    bool          `json:"success"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	DryRun     bool          `json:"dryRun"`
+	OccurredAt time.Time     `json:"occurredAt"`
+}
+
+// Publisher is implemented by every AssetResult destination, so Butler can
+// fan a result out without caring where it ends up.
+type Publisher interface {
+	Publish(ctx context.Context, result AssetResult) error
+	// Close releases any resources the publisher holds (connections,
+	// producers). Called once by cmd's post() after commandWG.Wait().
+	Close() error
+}
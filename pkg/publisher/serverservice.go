@@ -0,0 +1,80 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// serverServiceBMCAttributes is the subset of a serverservice BMC component
+// attributes patch bmcbutler knows how to populate.
+type serverServiceBMCAttributes struct {
+	Vendor               string `json:"vendor,omitempty"`
+	Model                string `json:"model,omitempty"`
+	Serial               string `json:"serial,omitempty"`
+	LastConfigureRun     string `json:"lastConfigureRun,omitempty"`
+	LastConfigureSuccess bool   `json:"lastConfigureSuccess"`
+}
+
+// ServerServicePublisher PATCHes an asset's BMC attributes back to a
+// serverservice-style inventory/CMDB after each Configure/Execute action.
+type ServerServicePublisher struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+// NewServerServicePublisher builds a ServerServicePublisher from cfg.
+func NewServerServicePublisher(cfg config.ServerServiceConfig) *ServerServicePublisher {
+	return &ServerServicePublisher{URL: cfg.URL, Token: cfg.Token, Client: &http.Client{Timeout: defaultPublishTimeout}}
+}
+
+// Publish implements Publisher.
+func (s *ServerServicePublisher) Publish(ctx context.Context, result AssetResult) error {
+	attrs := serverServiceBMCAttributes{
+		Vendor:               result.Asset.Vendor,
+		Model:                result.Asset.Model,
+		Serial:               result.Asset.Serial,
+		LastConfigureRun:     result.OccurredAt.Format(time.RFC3339),
+		LastConfigureSuccess: result.Success,
+	}
+
+	body, err := json.Marshal(attrs)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/v1/servers/%s/components/bmc", s.URL, result.Asset.Serial)
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("serverservice publisher: %s returned status %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Close implements Publisher. The serverservice publisher holds no resources.
+func (s *ServerServicePublisher) Close() error {
+	return nil
+}
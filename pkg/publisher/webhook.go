@@ -0,0 +1,70 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// defaultPublishTimeout bounds a single publish HTTP request, so a stalled
+// webhook/CMDB endpoint can't block a Fanout worker goroutine indefinitely.
+const defaultPublishTimeout = 30 * time.Second
+
+// WebhookPublisher POSTs each AssetResult as JSON to a webhook URL, signing
+// the body with HMAC-SHA256 when a secret is configured so the receiver can
+// verify it came from bmcbutler.
+type WebhookPublisher struct {
+	URL        string
+	HMACSecret string
+	Client     *http.Client
+}
+
+// NewWebhookPublisher builds a WebhookPublisher from cfg.
+func NewWebhookPublisher(cfg config.WebhookPublisherConfig) *WebhookPublisher {
+	return &WebhookPublisher{URL: cfg.URL, HMACSecret: cfg.HMACSecret, Client: &http.Client{Timeout: defaultPublishTimeout}}
+}
+
+// Publish implements Publisher.
+func (w *WebhookPublisher) Publish(ctx context.Context, result AssetResult) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if w.HMACSecret != "" {
+		mac := hmac.New(sha256.New, []byte(w.HMACSecret))
+		mac.Write(body)
+		req.Header.Set("X-Bmcbutler-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook publisher: %s returned status %d", w.URL, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Close implements Publisher. The webhook publisher holds no resources.
+func (w *WebhookPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,55 @@
+// Package report builds structured, per-asset records of what a butler run
+// actually did, and emits them through a pluggable ReportSink for post-run
+// auditing — e.g. "which servers actually got BIOS setting X applied last
+// night?".
+package report
+
+import (
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/asset"
+)
+
+// StepResult records the outcome of a single named step (e.g. "login",
+// "apply_configuration") carried out while actioning an asset.
+type StepResult struct {
+	Name       string    `json:"name"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// NewStep builds a StepResult for a step that started at startedAt and has
+// just finished, optionally with an error.
+func NewStep(name string, startedAt time.Time, err error) StepResult {
+	step := StepResult{
+		Name:       name,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+	}
+
+	if err != nil {
+		step.Error = err.Error()
+	}
+
+	return step
+}
+
+// RunReport is a structured record of a single butler run against an asset.
+type RunReport struct {
+	Asset      asset.Asset  `json:"asset"`
+	StartedAt  time.Time    `json:"startedAt"`
+	FinishedAt time.Time    `json:"finishedAt"`
+	Steps      []StepResult `json:"steps"`
+	Error      string       `json:"error,omitempty"`
+
+	// ConfigDigest is the SHA256 of the rendered configuration payload
+	// applied to the asset, so a report can be correlated to the exact
+	// templated config that produced it.
+	ConfigDigest string `json:"configDigest,omitempty"`
+}
+
+// AddStep appends a StepResult to the report.
+func (r *RunReport) AddStep(step StepResult) {
+	r.Steps = append(r.Steps, step)
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// ReportSink is implemented by every run report destination, so a Butler can
+// emit a RunReport without caring where it ends up.
+type ReportSink interface {
+	Emit(report RunReport) error
+}
+
+// StdoutSink writes each report as a single line of JSON to stdout.
+type StdoutSink struct{}
+
+// Emit implements ReportSink.
+func (StdoutSink) Emit(report RunReport) error {
+	out, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+// FileSink appends each report as a line of JSON to a file.
+type FileSink struct {
+	Path string
+}
+
+// Emit implements ReportSink.
+func (f FileSink) Emit(report RunReport) error {
+	out, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(out, '\n'))
+	return err
+}
+
+// WebhookSink POSTs each report as JSON to a webhook URL.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// Emit implements ReportSink.
+func (w WebhookSink) Emit(report RunReport) error {
+	out, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Post(w.URL, "application/json", bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook sink: %s returned status %d", w.URL, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// NewSink builds a ReportSink from cfg, defaulting to StdoutSink when Sink is unset.
+func NewSink(cfg config.ReportConfig) ReportSink {
+	switch cfg.Sink {
+	case "file":
+		return FileSink{Path: cfg.FilePath}
+	case "webhook":
+		return WebhookSink{URL: cfg.WebhookURL}
+	default:
+		return StdoutSink{}
+	}
+}
@@ -0,0 +1,44 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+)
+
+// GCPSecretManagerBackend resolves secrets from GCP Secret Manager, using
+// application default credentials. A reference's path is the secret's full
+// resource name (e.g. "projects/my-project/secrets/bmc-root-pw"), and
+// always reads its "latest" version; GCP secrets are single values, so a
+// fragment is ignored.
+type GCPSecretManagerBackend struct {
+	client *secretmanager.Client
+}
+
+// NewGCPSecretManagerBackend builds a GCPSecretManagerBackend.
+func NewGCPSecretManagerBackend(ctx context.Context) (*GCPSecretManagerBackend, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GCPSecretManagerBackend{client: client}, nil
+}
+
+// Get implements backend. key is ignored.
+func (g *GCPSecretManagerBackend) Get(path, key string) (string, error) {
+	result, err := g.client.AccessSecretVersion(context.Background(), &secretmanagerpb.AccessSecretVersionRequest{
+		Name: fmt.Sprintf("%s/versions/latest", path),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if result.Payload == nil {
+		return "", fmt.Errorf("gcp-sm: secret %q has no payload", path)
+	}
+
+	return string(result.Payload.Data), nil
+}
@@ -0,0 +1,38 @@
+package secrets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// EnvBackend resolves a secret from an environment variable named by the
+// reference's path, e.g. "env://BMC_ROOT_PW". Intended for local
+// development, where there's no real secrets backend to talk to.
+type EnvBackend struct{}
+
+// Get implements backend. key is ignored; path is the environment variable name.
+func (EnvBackend) Get(path, key string) (string, error) {
+	value, ok := os.LookupEnv(path)
+	if !ok {
+		return "", fmt.Errorf("env: %s is not set", path)
+	}
+
+	return value, nil
+}
+
+// FileBackend resolves a secret by reading a local file, e.g.
+// "file:///etc/bmcbutler/secrets/bmc-root-pw". Intended for local
+// development and CI, where secrets are dropped in by some other process.
+type FileBackend struct{}
+
+// Get implements backend. key is ignored; path is the filesystem path to read.
+func (FileBackend) Get(path, key string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// VaultBackend resolves secrets from a HashiCorp Vault KV store. A
+// reference's path is the secret's path and its fragment selects the field
+// within it, e.g. "vault://secret/data/bmc#password".
+type VaultBackend struct {
+	client *vaultapi.Client
+}
+
+// NewVaultBackend builds a VaultBackend from cfg.
+func NewVaultBackend(cfg config.VaultConfig) (*VaultBackend, error) {
+	vc := vaultapi.DefaultConfig()
+	vc.Address = cfg.Address
+
+	client, err := vaultapi.NewClient(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetToken(cfg.Token)
+
+	return &VaultBackend{client: client}, nil
+}
+
+// Get implements backend. Vault's KV v2 backend nests fields under a
+// "data" key; key defaults to "value" when unset, matching bmcbutler's own
+// convention for single-value secrets.
+func (v *VaultBackend) Get(path, key string) (string, error) {
+	secret, err := v.client.Logical().Read(path)
+	if err != nil {
+		return "", err
+	}
+
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("vault: no secret found at %q", path)
+	}
+
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	if key == "" {
+		key = "value"
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("vault: secret at %q has no field %q", path, key)
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("vault: field %q at %q is not a string", key, path)
+	}
+
+	return s, nil
+}
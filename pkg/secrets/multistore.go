@@ -0,0 +1,112 @@
+package secrets
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// backendFactory lazily constructs a backend the first time a reference
+// using its scheme is resolved, so e.g. bmcbutler doesn't need AWS/GCP
+// credentials configured when no reference ever uses them.
+type backendFactory func() (backend, error)
+
+// cacheEntry is a resolved secret value cached for TTL, so a high-fan-out
+// run doesn't re-fetch the same reference once per asset.
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// MultiStore is a Store that dispatches each reference to the backend
+// factory registered for its scheme, constructing (and memoizing) that
+// backend on first use, and caching resolved values for TTL.
+type MultiStore struct {
+	factories map[string]backendFactory
+	ttl       time.Duration
+
+	mu       sync.Mutex
+	backends map[string]backend
+	cache    map[string]cacheEntry
+}
+
+// NewMultiStore builds a MultiStore from the given scheme->factory map.
+// ttl <= 0 disables caching.
+func NewMultiStore(factories map[string]backendFactory, ttl time.Duration) *MultiStore {
+	return &MultiStore{
+		factories: factories,
+		ttl:       ttl,
+		backends:  make(map[string]backend),
+		cache:     make(map[string]cacheEntry),
+	}
+}
+
+// backendFor returns the backend for scheme, constructing it via its
+// factory on first use.
+func (m *MultiStore) backendFor(scheme string) (backend, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if b, ok := m.backends[scheme]; ok {
+		return b, nil
+	}
+
+	factory, ok := m.factories[scheme]
+	if !ok {
+		return nil, fmt.Errorf("no backend registered for scheme %q", scheme)
+	}
+
+	b, err := factory()
+	if err != nil {
+		return nil, err
+	}
+
+	m.backends[scheme] = b
+	return b, nil
+}
+
+// resolve resolves ref, serving a cached value when it's still within TTL.
+func (m *MultiStore) resolve(ref string) (string, error) {
+	if m.ttl > 0 {
+		m.mu.Lock()
+		entry, ok := m.cache[ref]
+		m.mu.Unlock()
+
+		if ok && time.Now().Before(entry.expiresAt) {
+			return entry.value, nil
+		}
+	}
+
+	scheme, path, key, err := parseRef(ref)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := m.backendFor(scheme)
+	if err != nil {
+		return "", fmt.Errorf("secrets: resolving %q: %s", ref, err)
+	}
+
+	value, err := b.Get(path, key)
+	if err != nil {
+		return "", fmt.Errorf("secrets: resolving %q: %s", ref, err)
+	}
+
+	if m.ttl > 0 {
+		m.mu.Lock()
+		m.cache[ref] = cacheEntry{value: value, expiresAt: time.Now().Add(m.ttl)}
+		m.mu.Unlock()
+	}
+
+	return value, nil
+}
+
+// GetCredential implements Store.
+func (m *MultiStore) GetCredential(ref string) (string, error) {
+	return m.resolve(ref)
+}
+
+// GetToken implements Store.
+func (m *MultiStore) GetToken(ref string) (string, error) {
+	return m.resolve(ref)
+}
@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// defaultCacheTTL bounds how long a resolved secret is cached before being
+// re-fetched from its backend, so a high-fan-out run doesn't hammer the
+// backend resolving the same reference once per asset.
+const defaultCacheTTL = 5 * time.Minute
+
+// Load builds a Store wired up with every backend scheme bmcbutler knows
+// how to resolve: "vault" (HashiCorp Vault, configured via cfg.Vault),
+// "aws-sm" (AWS Secrets Manager), "gcp-sm" (GCP Secret Manager), and "env"/
+// "file" for local development. Backends other than "vault" authenticate
+// via their own ambient credential chains and are constructed lazily, on
+// first use, so e.g. a run with no aws-sm:// references never needs AWS
+// credentials configured.
+func Load(cfg *config.Params) Store {
+	factories := map[string]backendFactory{
+		"env":  func() (backend, error) { return EnvBackend{}, nil },
+		"file": func() (backend, error) { return FileBackend{}, nil },
+		"aws-sm": func() (backend, error) {
+			return NewAWSSecretsManagerBackend()
+		},
+		"gcp-sm": func() (backend, error) {
+			return NewGCPSecretManagerBackend(context.Background())
+		},
+	}
+
+	if cfg.Vault != nil && cfg.Vault.Address != "" {
+		vaultCfg := *cfg.Vault
+		factories["vault"] = func() (backend, error) {
+			return NewVaultBackend(vaultCfg)
+		}
+	}
+
+	return NewMultiStore(factories, defaultCacheTTL)
+}
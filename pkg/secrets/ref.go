@@ -0,0 +1,23 @@
+package secrets
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// parseRef splits a URI-style secret reference into its backend scheme, the
+// combined host+path identifying the secret within that backend, and an
+// optional fragment selecting a single field within it, e.g.
+// "vault://secret/data/bmc#password" -> ("vault", "secret/data/bmc", "password").
+func parseRef(raw string) (scheme, path, key string, err error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid secret reference %q: %s", raw, err)
+	}
+
+	if u.Scheme == "" {
+		return "", "", "", fmt.Errorf("secret reference %q has no backend scheme (expected e.g. vault://, aws-sm://, gcp-sm://, env://, file://)", raw)
+	}
+
+	return u.Scheme, u.Host + u.Path, u.Fragment, nil
+}
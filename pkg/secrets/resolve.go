@@ -0,0 +1,59 @@
+package secrets
+
+import (
+	"strings"
+
+	"github.com/bmc-toolbox/bmcbutler/pkg/config"
+)
+
+// isRef reports whether s looks like a URI-style secret reference, as
+// opposed to a literal value, i.e. it has a "scheme://" prefix.
+func isRef(s string) bool {
+	return strings.Contains(s, "://")
+}
+
+// HasReferences reports whether cfg has any secret-reference values to
+// resolve - i.e. it's not specific to Vault, unlike Config.SecretsFromVault,
+// so a run using only e.g. aws-sm:// or env:// references doesn't need that
+// flag set to get them resolved.
+func HasReferences(cfg *config.Params) bool {
+	for _, cred := range cfg.Credentials {
+		for _, v := range cred {
+			if isRef(v) {
+				return true
+			}
+		}
+	}
+
+	return isRef(cfg.CertSigner.LemurSigner.Key)
+}
+
+// ResolveCredentials replaces every secret-reference value in credentials
+// with its resolved value, leaving literal (non-reference) values untouched
+// - so hardcoded and backend-resolved credentials can coexist, e.g. while
+// migrating a fleet's credentials off a config file and onto a backend.
+func ResolveCredentials(store Store, credentials []map[string]string) ([]map[string]string, error) {
+	resolved := make([]map[string]string, len(credentials))
+
+	for i, cred := range credentials {
+		out := make(map[string]string, len(cred))
+
+		for k, v := range cred {
+			if !isRef(v) {
+				out[k] = v
+				continue
+			}
+
+			value, err := store.GetCredential(v)
+			if err != nil {
+				return nil, err
+			}
+
+			out[k] = value
+		}
+
+		resolved[i] = out
+	}
+
+	return resolved, nil
+}
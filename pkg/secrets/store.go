@@ -0,0 +1,30 @@
+// Package secrets resolves credential and token references against one of
+// several pluggable backends (HashiCorp Vault, AWS Secrets Manager, GCP
+// Secret Manager, or a local file/env backend for development), so
+// different vendors or locations can draw secrets from different places
+// without the rest of bmcbutler caring which.
+package secrets
+
+// Store resolves a secret reference to its value. A reference is a
+// URI-style string whose scheme selects the backend, its host+path names
+// the secret within that backend, and an optional fragment selects a single
+// field within it, e.g.:
+//
+//	vault://secret/data/bmc#password
+//	aws-sm://prod/bmc/root#password
+//	gcp-sm://projects/my-project/secrets/bmc-root-pw
+//	env://BMC_ROOT_PW
+//	file:///etc/bmcbutler/secrets/bmc-root-pw
+type Store interface {
+	// GetCredential resolves ref to a BMC credential value.
+	GetCredential(ref string) (string, error)
+	// GetToken resolves ref to a token value, e.g. a cert signer API key.
+	GetToken(ref string) (string, error)
+}
+
+// backend is implemented by each secrets backend plugged into a MultiStore.
+// path and key are a reference's host+path and fragment, already split out
+// of its scheme by MultiStore.
+type backend interface {
+	Get(path, key string) (string, error)
+}
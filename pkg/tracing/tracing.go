@@ -0,0 +1,63 @@
+// Package tracing wires up OpenTelemetry so discover and configure can open
+// spans around probe/resource-apply work and correlate logrus lines to them.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies bmcbutler's own spans in the exported trace data.
+const TracerName = "github.com/bmc-toolbox/bmcbutler"
+
+// Init configures the global OpenTelemetry tracer provider to export spans
+// over OTLP to otlpEndpoint. If otlpEndpoint is empty, tracing is left as a
+// no-op provider and Init returns a nil shutdown func.
+func Init(otlpEndpoint string) (shutdown func(context.Context) error, err error) {
+	if otlpEndpoint == "" {
+		return nil, nil
+	}
+
+	exporter, err := otlptracegrpc.New(
+		context.Background(),
+		otlptracegrpc.WithEndpoint(otlpEndpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String("bmcbutler"))
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns bmcbutler's tracer. Safe to call even when Init was never
+// invoked - it then returns a no-op tracer.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// TraceID returns the hex-encoded trace ID carried by ctx's span context,
+// or "" if it carries none. A span that isn't recording (e.g. sampled out)
+// still yields its trace ID. Useful for attaching a trace ID to the
+// logrus fields already emitted alongside a span.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
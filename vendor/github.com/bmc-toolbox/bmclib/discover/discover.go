@@ -3,8 +3,11 @@ package discover
 import (
 	"context"
 	"crypto/x509"
+	"io"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/errors"
 	"github.com/bmc-toolbox/bmclib/internal/httpclient"
@@ -12,8 +15,15 @@ import (
 
 	"github.com/bmc-toolbox/bmclib/providers/dummy/ibmc"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
+// tracer uses whatever global TracerProvider the caller has configured
+// (e.g. via bmcbutler's pkg/tracing.Init); if none was set this is a no-op.
+var tracer = otel.Tracer("github.com/bmc-toolbox/bmclib/discover")
+
 const (
 	ProbeHpIlo         = "hpilo"
 	ProbeIdrac8        = "idrac8"
@@ -28,7 +38,10 @@ const (
 
 // ScanAndConnect will scan the BMC trying to deduce the device type and return a working connection.
 func ScanAndConnect(host string, username string, password string, options ...Option) (bmcConnection interface{}, err error) {
-	opts := &Options{HintCallback: func(_ string) error { return nil }}
+	opts := &Options{
+		HintCallback:  func(_ string) error { return nil },
+		ProbeObserver: func(_, _ string, _ time.Duration) {},
+	}
 	for _, optFn := range options {
 		optFn(opts)
 	}
@@ -83,12 +96,23 @@ func ScanAndConnect(host string, username string, password string, options ...Op
 		swapProbe(order, opts.Hint)
 	}
 
+	if opts.ParallelProbe > 1 {
+		return scanParallel(host, order, devices, opts)
+	}
+
 	for _, probeID := range order {
 		probeDevice := devices[probeID]
 
 		opts.Logger.V(1).Info("probing to identify device", "step", "ScanAndConnect", "host", host, "vendor", probeID)
 
-		bmcConnection, err := probeDevice(opts.Context, opts.Logger)
+		probeCtx, span := tracer.Start(opts.Context, "discover.probe")
+		span.SetAttributes(
+			attribute.String("host", host),
+			attribute.String("vendor", probeID),
+		)
+
+		probeStart := time.Now()
+		bmcConnection, err := probeDevice(probeCtx, opts.Logger)
 		// if the device didn't match continue to probe
 		if err != nil {
 			// log error if probe is not successful
@@ -98,8 +122,16 @@ func ScanAndConnect(host string, username string, password string, options ...Op
 				"vendor", probeID,
 				"Error", err,
 			)
+			opts.ProbeObserver(probeID, "failure", time.Since(probeStart))
+			span.SetAttributes(attribute.String("outcome", "failure"))
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
 			continue
 		}
+		opts.ProbeObserver(probeID, "success", time.Since(probeStart))
+		span.SetAttributes(attribute.String("outcome", "success"))
+		span.End()
+
 		if hintErr := opts.HintCallback(probeID); hintErr != nil {
 			return nil, hintErr
 		}
@@ -112,6 +144,112 @@ func ScanAndConnect(host string, username string, password string, options ...Op
 	return nil, errors.ErrVendorUnknown
 }
 
+// probeResult carries the outcome of a single probe run under scanParallel.
+type probeResult struct {
+	probeID string
+	conn    interface{}
+	err     error
+}
+
+// scanParallel runs up to opts.ParallelProbe probes concurrently, in the
+// order given (which already reflects the Hint/swapProbe scheduling
+// preference). The first probe to return a non-nil connection cancels the
+// remaining in-flight probes; their connections (if any arrive after
+// cancellation) are closed rather than leaked. HintCallback fires exactly
+// once, for the winning probeID.
+func scanParallel(host string, order []string, devices map[string]func(context.Context, logr.Logger) (interface{}, error), opts *Options) (interface{}, error) {
+	ctx, cancel := context.WithCancel(opts.Context)
+	defer cancel()
+
+	sem := make(chan struct{}, opts.ParallelProbe)
+	results := make(chan probeResult, len(order))
+
+	var wg sync.WaitGroup
+	for _, probeID := range order {
+		// Taking the slot here, not in the goroutine, starts probes in order.
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(probeID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if ctx.Err() != nil {
+				results <- probeResult{probeID: probeID, err: ctx.Err()}
+				return
+			}
+
+			probeCtx, span := tracer.Start(ctx, "discover.probe")
+			span.SetAttributes(
+				attribute.String("host", host),
+				attribute.String("vendor", probeID),
+			)
+
+			probeStart := time.Now()
+			conn, err := devices[probeID](probeCtx, opts.Logger)
+
+			outcome := "success"
+			if err != nil {
+				outcome = "failure"
+				span.SetStatus(codes.Error, err.Error())
+			}
+			opts.ProbeObserver(probeID, outcome, time.Since(probeStart))
+			span.SetAttributes(attribute.String("outcome", outcome))
+			span.End()
+
+			// A winner cancels the rest at once, so probes still waiting for
+			// a slot in the loop above give up as soon as they get one.
+			if err == nil && conn != nil {
+				cancel()
+			}
+
+			results <- probeResult{probeID: probeID, conn: conn, err: err}
+		}(probeID)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var winner *probeResult
+	for res := range results {
+		res := res // avoid aliasing across iterations
+
+		if res.err != nil || res.conn == nil {
+			continue
+		}
+
+		if winner != nil {
+			// A winner was already found - drain and close this loser to avoid FD leaks.
+			closeConnection(res.conn)
+			continue
+		}
+
+		winner = &res
+		// Cancel the rest of the in-flight probes now that we have a winner.
+		cancel()
+	}
+
+	if winner == nil {
+		return nil, errors.ErrVendorUnknown
+	}
+
+	if hintErr := opts.HintCallback(winner.probeID); hintErr != nil {
+		closeConnection(winner.conn)
+		return nil, hintErr
+	}
+
+	return winner.conn, nil
+}
+
+// closeConnection closes conn if it implements io.Closer, so losing probes
+// in scanParallel don't leak their underlying HTTP transports.
+func closeConnection(conn interface{}) {
+	if closer, ok := conn.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
 // Options to pass in
 type Options struct {
 	// Hint is a probe ID that hints which probe should be probed first.
@@ -122,8 +260,19 @@ type Options struct {
 	// If your code persists the hint as "best effort", always return a nil error.  Callback is
 	// synchronous.
 	HintCallback func(string) error
-	Logger       logr.Logger
-	Context      context.Context
+
+	// ProbeObserver, when set, is called after every probe attempt with the
+	// probeID, outcome ("success"/"failure") and how long the probe took.
+	// Callers can use this to feed per-vendor metrics without bmclib taking
+	// a dependency on any particular metrics backend.
+	ProbeObserver func(probeID string, outcome string, duration time.Duration)
+
+	// ParallelProbe, when greater than 1, runs up to that many vendor probes
+	// concurrently instead of strictly serially. Defaults to 1 (serial).
+	ParallelProbe int
+
+	Logger  logr.Logger
+	Context context.Context
 
 	secureTLS            bool
 	certPool             *x509.CertPool
@@ -155,6 +304,17 @@ func WithHintCallBack(fn func(string) error) Option {
 	return func(args *Options) { args.HintCallback = fn }
 }
 
+// WithProbeObserver sets the Options.ProbeObserver option.
+func WithProbeObserver(fn func(probeID string, outcome string, duration time.Duration)) Option {
+	return func(args *Options) { args.ProbeObserver = fn }
+}
+
+// WithParallelProbe sets the Options.ParallelProbe option, running up to n
+// vendor probes concurrently. n <= 1 preserves the default serial behavior.
+func WithParallelProbe(n int) Option {
+	return func(args *Options) { args.ParallelProbe = n }
+}
+
 // WithLogger sets the Options.Logger option
 func WithLogger(log logr.Logger) Option { return func(args *Options) { args.Logger = log } }
 
@@ -15,8 +15,10 @@
 package bmclogin
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/bmc-toolbox/bmclib/devices"
 	"github.com/bmc-toolbox/bmclib/discover"
@@ -27,6 +29,37 @@ type Params struct {
 	Credentials     []map[string]string //A slice of username, passwords to login with.
 	CheckCredential bool                //Validates the credential works - this is only required for http(s) connections.
 	Retries         int                 //The number of times to retry a credential
+
+	// ProbeObserver, when set, is passed down to discover.ScanAndConnect to
+	// record per-vendor probe attempts/outcomes/latency.
+	ProbeObserver func(probeID string, outcome string, duration time.Duration)
+
+	// Context, when set, is passed down to discover.ScanAndConnect so probes
+	// abort promptly on cancellation (e.g. a graceful shutdown).
+	Context context.Context
+
+	// StopChan, when set, is checked before each credential/IP/retry
+	// attempt. If it's closed, Login returns ErrInterrupted instead of
+	// churning through the rest of the retry matrix.
+	StopChan <-chan struct{}
+}
+
+// ErrInterrupted is returned by Login when StopChan fires mid-attempt, so
+// callers can distinguish a graceful shutdown from a real login failure.
+var ErrInterrupted = errors.New("login attempts interrupted by shutdown")
+
+// interrupted reports whether a shutdown has been requested via StopChan.
+func (p *Params) interrupted() bool {
+	if p.StopChan == nil {
+		return false
+	}
+
+	select {
+	case <-p.StopChan:
+		return true
+	default:
+		return false
+	}
 }
 
 type LoginInfo struct {
@@ -45,6 +78,9 @@ func (p *Params) Login() (connection interface{}, loginInfo LoginInfo, err error
 
 	//for credential map in slice
 	for _, credentials := range p.Credentials {
+		if p.interrupted() {
+			return connection, loginInfo, ErrInterrupted
+		}
 
 		//for each credential k, v
 		for user, pass := range credentials {
@@ -55,8 +91,15 @@ func (p *Params) Login() (connection interface{}, loginInfo LoginInfo, err error
 					continue
 				}
 
+				if p.interrupted() {
+					return connection, loginInfo, ErrInterrupted
+				}
+
 				//for each retry attempt
 				for t := 0; t <= p.Retries; t++ {
+					if p.interrupted() {
+						return connection, loginInfo, ErrInterrupted
+					}
 
 					loginInfo.Attempts += 1
 					connection, ipInactive, err := p.attemptLogin(ip, user, pass)
@@ -94,7 +137,15 @@ func (p *Params) Login() (connection interface{}, loginInfo LoginInfo, err error
 func (p *Params) attemptLogin(ip string, user string, pass string) (connection interface{}, ipInactive bool, err error) {
 
 	// Scan BMC type and connect
-	connection, err = discover.ScanAndConnect(ip, user, pass)
+	var opts []discover.Option
+	if p.ProbeObserver != nil {
+		opts = append(opts, discover.WithProbeObserver(p.ProbeObserver))
+	}
+	if p.Context != nil {
+		opts = append(opts, discover.WithContext(p.Context))
+	}
+
+	connection, err = discover.ScanAndConnect(ip, user, pass, opts...)
 	if err != nil {
 		return connection, ipInactive, errors.New("ScanAndConnect attempt unsuccessful.")
 	}